@@ -0,0 +1,29 @@
+// Copyright 2026, The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cmpgen provides generic wrappers around [cmp.Equal] and [cmp.Diff]
+// that statically require both values to have the same type.
+//
+// The wrappers only add a compile-time type check. The values are passed
+// to [cmp.Equal] and [cmp.Diff] as interface{} values as usual, so they
+// neither avoid boxing the values nor perform any faster.
+// As with cmp, a type parameter that is an interface type is not preserved;
+// the values are compared according to their dynamic types.
+package cmpgen
+
+import "github.com/google/go-cmp/cmp"
+
+// EqualTyped reports whether x and y are equal.
+// It is identical to [cmp.Equal], except that the compiler checks
+// that x and y are of the same type T.
+func EqualTyped[T any](x, y T, opts ...cmp.Option) bool {
+	return cmp.Equal(x, y, opts...)
+}
+
+// DiffTyped returns a human-readable report of the differences between
+// two values. It is identical to [cmp.Diff], except that the compiler checks
+// that x and y are of the same type T.
+func DiffTyped[T any](x, y T, opts ...cmp.Option) string {
+	return cmp.Diff(x, y, opts...)
+}
@@ -0,0 +1,60 @@
+// Copyright 2026, The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmpgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTyped(t *testing.T) {
+	type S struct{ A, B int }
+	tests := []struct {
+		label     string
+		x, y      S
+		opts      []cmp.Option
+		wantEqual bool
+	}{{
+		label:     "Equal",
+		x:         S{1, 2},
+		y:         S{1, 2},
+		wantEqual: true,
+	}, {
+		label:     "Unequal",
+		x:         S{1, 2},
+		y:         S{1, 3},
+		wantEqual: false,
+	}, {
+		label:     "Options",
+		x:         S{1, 2},
+		y:         S{1, 3},
+		opts:      []cmp.Option{cmp.Comparer(func(x, y S) bool { return x.A == y.A })},
+		wantEqual: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			if got := EqualTyped(tt.x, tt.y, tt.opts...); got != tt.wantEqual {
+				t.Errorf("EqualTyped() = %v, want %v", got, tt.wantEqual)
+			}
+			gotDiff := DiffTyped(tt.x, tt.y, tt.opts...)
+			if wantDiff := cmp.Diff(tt.x, tt.y, tt.opts...); gotDiff != wantDiff {
+				t.Errorf("DiffTyped() mismatch with cmp.Diff:\ngot:\n%s\nwant:\n%s", gotDiff, wantDiff)
+			}
+			if (gotDiff == "") != tt.wantEqual {
+				t.Errorf("DiffTyped() = %q, want empty: %v", gotDiff, tt.wantEqual)
+			}
+		})
+	}
+}
+
+func TestTypedInterface(t *testing.T) {
+	var x, y interface{ String() string } = &strings.Builder{}, nil
+	if EqualTyped(x, y) {
+		t.Errorf("EqualTyped() = true, want false")
+	}
+}
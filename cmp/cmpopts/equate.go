@@ -6,10 +6,14 @@
 package cmpopts
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
+	"net/url"
+	"path"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
@@ -19,6 +23,11 @@ func equateAlways(_, _ interface{}) bool { return true }
 
 // EquateEmpty returns a [cmp.Comparer] option that determines all maps and slices
 // with a length of zero to be equal, regardless of whether they are nil.
+// Similarly, a nil channel is determined to be equal to
+// a non-nil unbuffered channel (i.e., with a capacity of zero),
+// while two non-nil channels are still compared as usual.
+// A nil pointer to a map or slice type is determined to be equal to
+// a non-nil pointer to a map or slice with a length of zero.
 //
 // EquateEmpty can be used in conjunction with [SortSlices] and [SortMaps].
 func EquateEmpty() cmp.Option {
@@ -27,11 +36,63 @@ func EquateEmpty() cmp.Option {
 
 func isEmpty(x, y interface{}) bool {
 	vx, vy := reflect.ValueOf(x), reflect.ValueOf(y)
-	return (x != nil && y != nil && vx.Type() == vy.Type()) &&
-		(vx.Kind() == reflect.Slice || vx.Kind() == reflect.Map) &&
+	if !(x != nil && y != nil && vx.Type() == vy.Type()) {
+		return false
+	}
+	if vx.Kind() == reflect.Ptr {
+		// If neither pointer is nil, then the pointed-at values are compared.
+		return vx.IsNil() != vy.IsNil() && isNilOrEmptyPtr(vx) && isNilOrEmptyPtr(vy)
+	}
+	if vx.Kind() == reflect.Chan {
+		// If neither channel is nil, then the channels are compared as usual.
+		return vx.IsNil() != vy.IsNil() && vx.Cap() == 0 && vy.Cap() == 0
+	}
+	return (vx.Kind() == reflect.Slice || vx.Kind() == reflect.Map) &&
 		(vx.Len() == 0 && vy.Len() == 0)
 }
 
+// isNilOrEmptyPtr reports whether v is a nil pointer to a slice or map,
+// or a pointer to a slice or map with a length of zero.
+func isNilOrEmptyPtr(v reflect.Value) bool {
+	if k := v.Type().Elem().Kind(); k != reflect.Slice && k != reflect.Map {
+		return false
+	}
+	return v.IsNil() || v.Elem().Len() == 0
+}
+
+// EquateNilPointers returns a [cmp.Option] that determines a nil *T to be
+// equal to a non-nil *T that points to the zero value of T,
+// where T is one of the given types.
+// The types are specified by passing in a value of each type.
+// The nil pointer is transformed into a pointer to a new zero value of T
+// and then compared as usual.
+func EquateNilPointers(typs ...interface{}) cmp.Option {
+	types := make(typesFilter)
+	for _, typ := range typs {
+		t := reflect.TypeOf(typ)
+		if t == nil {
+			panic("cannot determine type of nil interface value")
+		}
+		types[t] = true
+	}
+	nf := nilPointerFilter(types)
+	return cmp.FilterValues(nf.filter, cmp.Transformer("cmpopts.EquateNilPointers", nf.transform))
+}
+
+type nilPointerFilter typesFilter
+
+func (nf nilPointerFilter) filter(x, y interface{}) bool {
+	vx, vy := reflect.ValueOf(x), reflect.ValueOf(y)
+	return x != nil && y != nil && vx.Type() == vy.Type() &&
+		vx.Kind() == reflect.Ptr && nf[vx.Type().Elem()] && vx.IsNil() != vy.IsNil()
+}
+func (nilPointerFilter) transform(x interface{}) interface{} {
+	if v := reflect.ValueOf(x); v.IsNil() {
+		return reflect.New(v.Type().Elem()).Interface()
+	}
+	return x
+}
+
 // EquateApprox returns a [cmp.Comparer] option that determines float32 or float64
 // values to be equal if they are within a relative fraction or absolute margin.
 // This option is not used when either x or y is NaN or infinite.
@@ -124,6 +185,31 @@ func (a timeApproximator) compare(x, y time.Time) bool {
 	return !x.Add(a.margin).Before(y)
 }
 
+// EquateDurations returns a [cmp.Comparer] option that determines two
+// [time.Duration] values to be equal if they are within some margin of one another.
+// The margin must be non-negative.
+func EquateDurations(margin time.Duration) cmp.Option {
+	if margin < 0 {
+		panic("margin must be a non-negative number")
+	}
+	a := durationApproximator{margin}
+	return cmp.Comparer(a.compare)
+}
+
+type durationApproximator struct {
+	margin time.Duration
+}
+
+func (a durationApproximator) compare(x, y time.Duration) bool {
+	if x > y {
+		// Ensure x is always less than y
+		x, y = y, x
+	}
+	// Compute the difference as unsigned to avoid overflow when the
+	// difference is larger than the largest representable duration.
+	return uint64(y)-uint64(x) <= uint64(a.margin)
+}
+
 // AnyError is an error that matches any non-nil error.
 var AnyError anyError
 
@@ -156,6 +242,24 @@ func compareErrors(x, y interface{}) bool {
 	return errors.Is(xe, ye) || errors.Is(ye, xe)
 }
 
+// EquateFuncs returns a [cmp.Comparer] option that determines functions of
+// the same type to be equal if they are both nil or both refer to the same
+// function implementation, as reported by [reflect.Value.Pointer].
+// Closures created by the same function literal share an implementation,
+// so they are reported as equal regardless of the variables they capture.
+func EquateFuncs() cmp.Option {
+	return cmp.FilterValues(areFuncs, cmp.Comparer(equateFuncs))
+}
+
+func areFuncs(x, y interface{}) bool {
+	vx, vy := reflect.ValueOf(x), reflect.ValueOf(y)
+	return x != nil && y != nil && vx.Type() == vy.Type() && vx.Kind() == reflect.Func
+}
+
+func equateFuncs(x, y interface{}) bool {
+	return reflect.ValueOf(x).Pointer() == reflect.ValueOf(y).Pointer()
+}
+
 // EquateComparable returns a [cmp.Option] that determines equality
 // of comparable types by directly comparing them using the == operator in Go.
 // The types to compare are specified by passing a value of that type.
@@ -163,7 +267,18 @@ func compareErrors(x, y interface{}) bool {
 // safe for direct == comparison. For example, [net/netip.Addr] is documented
 // as being semantically safe to use with ==, while [time.Time] is documented
 // to discourage the use of == on time values.
+//
+// If no types are specified, then the option applies to every type that is
+// strictly comparable and has no Equal method. A type is strictly comparable
+// if == can never panic on it and never compares it shallowly. Thus,
+// interfaces, pointers, and unsafe pointers, or composite types containing
+// them, are never directly compared. Since this applies broadly,
+// it may conflict with any other [cmp.Comparer] or [cmp.Transformer]
+// that applies to the same basic types.
 func EquateComparable(typs ...interface{}) cmp.Option {
+	if len(typs) == 0 {
+		return cmp.FilterPath(isStrictlyComparable, cmp.Comparer(equateAny))
+	}
 	types := make(typesFilter)
 	for _, typ := range typs {
 		switch t := reflect.TypeOf(typ); {
@@ -183,3 +298,179 @@ type typesFilter map[reflect.Type]bool
 func (tf typesFilter) filter(p cmp.Path) bool { return tf[p.Last().Type()] }
 
 func equateAny(x, y interface{}) bool { return x == y }
+
+func isStrictlyComparable(p cmp.Path) bool {
+	t := p.Last().Type()
+	if t == nil || !t.Comparable() {
+		return false
+	}
+	if _, ok := t.MethodByName("Equal"); ok {
+		return false
+	}
+	if _, ok := reflect.PointerTo(t).MethodByName("Equal"); ok {
+		return false
+	}
+	return !hasShallowEquality(t)
+}
+
+// hasShallowEquality reports whether == on t may panic or
+// may compare by reference rather than by value.
+func hasShallowEquality(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface, reflect.Ptr, reflect.UnsafePointer:
+		return true
+	case reflect.Array:
+		return hasShallowEquality(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasShallowEquality(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// EquateJSON returns a [cmp.Option] that determines string or []byte values
+// to be equal if both contain valid JSON that decodes to equal values.
+// The values are decoded using [encoding/json.Unmarshal] into an empty
+// interface such that objects become map[string]interface{} and
+// numbers become float64. Thus, the order of object members and
+// insignificant whitespace do not affect equality.
+// If either value is not valid JSON, then they are compared as usual.
+//
+// This option applies to every string and []byte that contains valid JSON
+// (including strings like "true" or "0"). Use [cmp.FilterPath] to restrict
+// which values are treated as JSON.
+func EquateJSON() cmp.Option {
+	return cmp.FilterValues(areValidJSON, AcyclicTransformer("cmpopts.EquateJSON", decodeJSON))
+}
+
+func areValidJSON(x, y interface{}) bool {
+	bx, okx := jsonBytes(x)
+	by, oky := jsonBytes(y)
+	return okx && oky && reflect.TypeOf(x) == reflect.TypeOf(y) && json.Valid(bx) && json.Valid(by)
+}
+
+func jsonBytes(v interface{}) ([]byte, bool) {
+	switch v := v.(type) {
+	case []byte:
+		return v, true
+	case string:
+		return []byte(v), true
+	default:
+		return nil, false
+	}
+}
+
+func decodeJSON(v interface{}) interface{} {
+	b, _ := jsonBytes(v)
+	var out interface{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		panic(fmt.Sprintf("invalid JSON: %v", err)) // Must be valid because of areValidJSON
+	}
+	return out
+}
+
+// EquateUnorderedSlices returns a [cmp.Option] that determines two slices
+// with element type T to be equal if they contain the same elements with
+// the same number of occurrences, regardless of their order.
+// The element type is specified by passing in a value of that type,
+// which must be comparable.
+//
+// Each slice is transformed into a map from each element to the number of
+// times it occurs, such that elements are compared using the == operator
+// rather than the remaining options. To compare elements using the remaining
+// options, use [SortSlices] instead.
+//
+// EquateUnorderedSlices can be used in conjunction with [EquateEmpty].
+func EquateUnorderedSlices(typ interface{}) cmp.Option {
+	t := reflect.TypeOf(typ)
+	if t == nil || !t.Comparable() {
+		panic(fmt.Sprintf("%T is not a comparable Go type", typ))
+	}
+	sc := sliceCounter{t}
+	return cmp.FilterValues(sc.filter, cmp.Transformer("cmpopts.EquateUnorderedSlices", sc.count))
+}
+
+type sliceCounter struct {
+	elem reflect.Type // T
+}
+
+func (sc sliceCounter) filter(x, y interface{}) bool {
+	vx, vy := reflect.ValueOf(x), reflect.ValueOf(y)
+	return x != nil && y != nil && vx.Type() == vy.Type() &&
+		vx.Kind() == reflect.Slice && vx.Type().Elem() == sc.elem &&
+		(vx.Len() > 1 || vy.Len() > 1)
+}
+func (sc sliceCounter) count(x interface{}) interface{} {
+	src := reflect.ValueOf(x)
+	dst := reflect.MakeMapWithSize(reflect.MapOf(sc.elem, reflect.TypeOf(0)), src.Len())
+	for i := 0; i < src.Len(); i++ {
+		k := src.Index(i)
+		n := 0
+		if v := dst.MapIndex(k); v.IsValid() {
+			n = int(v.Int())
+		}
+		dst.SetMapIndex(k, reflect.ValueOf(n+1))
+	}
+	return dst.Interface()
+}
+
+// URLEquateOptions configures the normalization performed by [EquateURLs].
+type URLEquateOptions struct {
+	// DropDefaultPorts removes the port from the host if it is the default
+	// port for the scheme (i.e., 80 for "http" and 443 for "https").
+	DropDefaultPorts bool
+
+	// CleanPaths cleans the path using [path.Clean],
+	// preserving any trailing slash.
+	CleanPaths bool
+}
+
+// EquateURLs returns a [cmp.Comparer] option that determines two [url.URL]
+// values to be equal if they are equal after normalization.
+// Each URL is canonicalized by formatting it with [url.URL.String] and
+// parsing the result again with [url.Parse].
+// Further normalization is configured by opts, of which at most one
+// may be provided. If none is provided, no further normalization is performed.
+func EquateURLs(opts ...URLEquateOptions) cmp.Option {
+	var e urlEquater
+	switch len(opts) {
+	case 0:
+	case 1:
+		e.opts = opts[0]
+	default:
+		panic("at most one URLEquateOptions may be provided")
+	}
+	return cmp.Comparer(e.compare)
+}
+
+type urlEquater struct {
+	opts URLEquateOptions
+}
+
+func (e urlEquater) compare(x, y url.URL) bool {
+	return e.normalize(x) == e.normalize(y)
+}
+func (e urlEquater) normalize(u url.URL) string {
+	s := u.String()
+	pu, err := url.Parse(s)
+	if err != nil {
+		return s
+	}
+	if e.opts.DropDefaultPorts {
+		switch port := pu.Port(); {
+		case pu.Scheme == "http" && port == "80", pu.Scheme == "https" && port == "443":
+			pu.Host = strings.TrimSuffix(pu.Host, ":"+port)
+		}
+	}
+	if e.opts.CleanPaths && pu.Path != "" {
+		p := path.Clean(pu.Path)
+		if strings.HasSuffix(pu.Path, "/") && !strings.HasSuffix(p, "/") {
+			p += "/"
+		}
+		pu.Path, pu.RawPath = p, ""
+	}
+	return pu.String()
+}
@@ -7,6 +7,7 @@ package cmpopts
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"unicode"
 	"unicode/utf8"
 
@@ -21,11 +22,94 @@ import (
 //
 // The name may be a dot-delimited string (e.g., "Foo.Bar") to ignore a
 // specific sub-field that is embedded or nested within the parent struct.
+// Pointers to structs along the selector are automatically dereferenced.
+// If an intermediate pointer is nil on only one side, the pointers themselves
+// differ and that difference is still reported.
+// A field name may be followed by one or more "[*]" suffixes
+// (e.g., "Entries[*].Name") to select a sub-field within every element
+// of a slice, array, or map.
+//
+// If typ is nil, then the named fields are ignored on every struct type,
+// including unnamed struct types. In that case, the names cannot be
+// validated and fields forwarded by struct embedding must be selected
+// through the name of the embedded field (e.g., "Embedded.Foo").
 func IgnoreFields(typ interface{}, names ...string) cmp.Option {
 	sf := newStructFilter(typ, names...)
 	return cmp.FilterPath(sf.filter, cmp.Ignore())
 }
 
+// IgnoreFieldsInTypes returns an [cmp.Option] that ignores fields of the
+// given names on each of several struct types, which are specified by
+// passing in a value of each type. It is equivalent to combining
+// [IgnoreFields] for each of the types, where every name must exist
+// in every type.
+func IgnoreFieldsInTypes(names []string, typs ...interface{}) cmp.Option {
+	var sfs structFilters
+	for _, typ := range typs {
+		sfs = append(sfs, newStructFilter(typ, names...))
+	}
+	return cmp.FilterPath(sfs.filter, cmp.Ignore())
+}
+
+type structFilters []structFilter
+
+func (sfs structFilters) filter(p cmp.Path) bool {
+	for _, sf := range sfs {
+		if sf.filter(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// IgnoreFieldsByPrefix returns an [cmp.Option] that ignores the immediate
+// fields of a single struct type whose names begin with any of the
+// given prefixes. The struct type is specified by passing in a value of that type.
+func IgnoreFieldsByPrefix(typ interface{}, prefixes ...string) cmp.Option {
+	t := reflect.TypeOf(typ)
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("%T must be a non-pointer struct", typ))
+	}
+	pf := prefixFilter{t, prefixes}
+	return cmp.FilterPath(pf.filter, cmp.Ignore())
+}
+
+type prefixFilter struct {
+	t        reflect.Type
+	prefixes []string
+}
+
+func (pf prefixFilter) filter(p cmp.Path) bool {
+	sf, ok := p.Index(-1).(cmp.StructField)
+	if !ok || p.Index(-2).Type() != pf.t {
+		return false
+	}
+	for _, prefix := range pf.prefixes {
+		if strings.HasPrefix(sf.Name(), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// IgnoreFieldsByTag returns an [cmp.Option] that ignores struct fields
+// of any struct type where the value associated with tagKey in the
+// field's struct tag is exactly tagValue.
+//
+// For example, IgnoreFieldsByTag("json", "-") ignores all fields
+// that are tagged with `json:"-"`.
+func IgnoreFieldsByTag(tagKey, tagValue string) cmp.Option {
+	tf := tagFilter{tagKey, tagValue}
+	return cmp.FilterPath(tf.filter, cmp.Ignore())
+}
+
+type tagFilter struct{ key, val string }
+
+func (tf tagFilter) filter(p cmp.Path) bool {
+	sf, ok := p.Index(-1).(cmp.StructField)
+	return ok && sf.Field().Tag.Get(tf.key) == tf.val
+}
+
 // IgnoreTypes returns an [cmp.Option] that ignores all values assignable to
 // certain types, which are specified by passing in a value of each type.
 func IgnoreTypes(typs ...interface{}) cmp.Option {
@@ -116,30 +200,261 @@ func (tf ifaceFilter) filter(p cmp.Path) bool {
 // Avoid ignoring unexported fields of a type which you do not control (i.e. a
 // type from another repository), as changes to the implementation of such types
 // may change how the comparison behaves. Prefer a custom [cmp.Comparer] instead.
+//
+// The struct types are specified by passing in a value of that type
+// or the [reflect.Type] of the struct itself.
 func IgnoreUnexported(typs ...interface{}) cmp.Option {
 	ux := newUnexportedFilter(typs...)
 	return cmp.FilterPath(ux.filter, cmp.Ignore())
 }
 
-type unexportedFilter struct{ m map[reflect.Type]bool }
+type unexportedFilter struct{ typs typeSet }
 
 func newUnexportedFilter(typs ...interface{}) unexportedFilter {
-	ux := unexportedFilter{m: make(map[reflect.Type]bool)}
+	return unexportedFilter{typs: newTypeSet(typs...)}
+}
+func (xf unexportedFilter) filter(p cmp.Path) bool {
+	sf, ok := p.Index(-1).(cmp.StructField)
+	if !ok {
+		return false
+	}
+	return xf.typs[p.Index(-2).Type()] && !isExported(sf.Name())
+}
+
+// typeSet is a set of struct types, each specified by a value of that type
+// or the reflect.Type of the struct itself.
+type typeSet map[reflect.Type]bool
+
+func newTypeSet(typs ...interface{}) typeSet {
+	ts := make(typeSet)
 	for _, typ := range typs {
-		t := reflect.TypeOf(typ)
+		t, ok := typ.(reflect.Type)
+		if !ok {
+			t = reflect.TypeOf(typ)
+		}
 		if t == nil || t.Kind() != reflect.Struct {
-			panic(fmt.Sprintf("%T must be a non-pointer struct", typ))
+			panic(fmt.Sprintf("%v must be a non-pointer struct", t))
 		}
-		ux.m[t] = true
+		ts[t] = true
 	}
-	return ux
+	return ts
 }
-func (xf unexportedFilter) filter(p cmp.Path) bool {
+
+// IgnoreAllUnexported returns an [cmp.Option] that ignores all unexported
+// fields of every struct type, including anonymous fields of unexported types.
+// Unlike [IgnoreUnexported], the struct types do not need to be listed.
+//
+// Use this option with great care. Unexported fields frequently hold
+// meaningful state, and ignoring all of them may cause two values to be
+// reported as equal even though they behave differently.
+// Prefer [IgnoreUnexported] on types you control or a custom [cmp.Comparer]
+// on types you do not control.
+func IgnoreAllUnexported() cmp.Option {
+	return cmp.FilterPath(isUnexportedField, cmp.Ignore())
+}
+
+func isUnexportedField(p cmp.Path) bool {
 	sf, ok := p.Index(-1).(cmp.StructField)
-	if !ok {
+	return ok && !isExported(sf.Name())
+}
+
+// IgnoreUnexportedInPkg returns an [cmp.Option] that ignores the unexported
+// fields of every struct type, where the field was declared in the package
+// with exactly the given package path (e.g., "example.com/mod/pkg").
+// Unexported fields of types declared in sub-packages are not ignored.
+//
+// See [IgnoreUnexported] for the hazards of ignoring unexported fields.
+func IgnoreUnexportedInPkg(pkg string) cmp.Option {
+	if pkg == "" {
+		panic("package path must not be empty")
+	}
+	pf := pkgFilter(func(p string) bool { return p == pkg })
+	return cmp.FilterPath(pf.filter, cmp.Ignore())
+}
+
+// IgnoreUnexportedInModule returns an [cmp.Option] that ignores the unexported
+// fields of every struct type, where the field was declared in a package whose
+// path is the given module path or any package path under it
+// (e.g., "example.com/mod" matches "example.com/mod/pkg", but not
+// "example.com/module").
+//
+// See [IgnoreUnexported] for the hazards of ignoring unexported fields.
+func IgnoreUnexportedInModule(module string) cmp.Option {
+	if module == "" {
+		panic("module path must not be empty")
+	}
+	module = strings.TrimSuffix(module, "/")
+	pf := pkgFilter(func(p string) bool { return p == module || strings.HasPrefix(p, module+"/") })
+	return cmp.FilterPath(pf.filter, cmp.Ignore())
+}
+
+// pkgFilter matches unexported struct fields by their package path.
+type pkgFilter func(pkgPath string) bool
+
+func (pf pkgFilter) filter(p cmp.Path) bool {
+	sf, ok := p.Index(-1).(cmp.StructField)
+	return ok && !isExported(sf.Name()) && pf(sf.Field().PkgPath)
+}
+
+// IgnoreZeroFields returns an [cmp.Option] that ignores the immediate fields
+// of the given struct types if the field is the zero value in both x and y.
+// A field that is zero on only one side is still compared as usual.
+// The struct types are specified by passing in a value of each type.
+func IgnoreZeroFields(typs ...interface{}) cmp.Option {
+	zf := zeroFieldFilter{typs: newTypeSet(typs...)}
+	return cmp.FilterPath(zf.filter, cmp.Ignore())
+}
+
+type zeroFieldFilter struct{ typs typeSet }
+
+func (zf zeroFieldFilter) filter(p cmp.Path) bool {
+	sf, ok := p.Index(-1).(cmp.StructField)
+	if !ok || !zf.typs[p.Index(-2).Type()] {
+		return false
+	}
+	vx, vy := sf.Values()
+	return vx.IsValid() && vy.IsValid() && vx.IsZero() && vy.IsZero()
+}
+
+// IgnoreEmpty returns an [cmp.Option] that ignores the immediate fields
+// of the given struct types if the field is empty in both x and y.
+// A field is empty if it is the zero value or if it is
+// a slice, map, or string with a length of zero.
+// Thus, unlike [IgnoreZeroFields], a nil slice and an empty slice are both
+// considered empty. A field that is empty on only one side is still compared
+// as usual. The struct types are specified by passing in a value of each type.
+func IgnoreEmpty(typs ...interface{}) cmp.Option {
+	ef := emptyFieldFilter{typs: newTypeSet(typs...)}
+	return cmp.FilterPath(ef.filter, cmp.Ignore())
+}
+
+type emptyFieldFilter struct{ typs typeSet }
+
+func (ef emptyFieldFilter) filter(p cmp.Path) bool {
+	sf, ok := p.Index(-1).(cmp.StructField)
+	if !ok || !ef.typs[p.Index(-2).Type()] {
+		return false
+	}
+	vx, vy := sf.Values()
+	return vx.IsValid() && vy.IsValid() && isEmptyValue(vx) && isEmptyValue(vy)
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.String:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+// IgnoreFieldsNotIn returns an [cmp.Option] that ignores the immediate fields
+// of the template's struct type that are the zero value in the template,
+// such that only the fields explicitly set in the template are compared.
+// This complements [IgnoreZeroFields], which inspects the values being
+// compared rather than a template.
+// The template must be a non-pointer struct.
+func IgnoreFieldsNotIn(template interface{}) cmp.Option {
+	v := reflect.ValueOf(template)
+	if template == nil || v.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("%T must be a non-pointer struct", template))
+	}
+	tf := templateFilter{t: v.Type(), set: make(map[int]bool)}
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Field(i).IsZero() {
+			tf.set[i] = true
+		}
+	}
+	return cmp.FilterPath(tf.filter, cmp.Ignore())
+}
+
+type templateFilter struct {
+	t   reflect.Type // The struct type
+	set map[int]bool // Indexes of the fields to compare
+}
+
+func (tf templateFilter) filter(p cmp.Path) bool {
+	sf, ok := p.Index(-1).(cmp.StructField)
+	return ok && p.Index(-2).Type() == tf.t && !tf.set[sf.Index()]
+}
+
+// OnlyCompareFields returns an [cmp.Option] that ignores all immediate fields
+// of a single struct type except for those with the given names.
+// It is the complement of [IgnoreFields], but only accepts the names of
+// fields directly within the struct. The struct type is specified by passing
+// in a value of that type.
+func OnlyCompareFields(typ interface{}, names ...string) cmp.Option {
+	t := reflect.TypeOf(typ)
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("%T must be a non-pointer struct", typ))
+	}
+	tf := templateFilter{t: t, set: make(map[int]bool)}
+	for _, name := range names {
+		sf, ok := t.FieldByName(name)
+		if !ok || len(sf.Index) != 1 {
+			panic(fmt.Sprintf("%s: does not exist in %v", name, t))
+		}
+		tf.set[sf.Index[0]] = true
+	}
+	return cmp.FilterPath(tf.filter, cmp.Ignore())
+}
+
+// IgnoreByValue returns an [cmp.Option] that ignores values that are equal
+// to any of the given sentinel values in both x and y, such as a -1 that
+// denotes an unset identifier. A value only matches a sentinel of the same
+// type and is compared using the == operator.
+// A value that matches a sentinel on only one side is still compared as usual.
+// It panics if any sentinel is not of a comparable type,
+// or if it can never match because it is or contains a NaN.
+func IgnoreByValue(sentinels ...interface{}) cmp.Option {
+	sf := make(sentinelFilter)
+	for _, v := range sentinels {
+		t := reflect.TypeOf(v)
+		if t == nil || !t.Comparable() {
+			panic(fmt.Sprintf("%T is not a comparable Go type", v))
+		}
+		rv := reflect.ValueOf(v)
+		switch eq, ok := compareValues(rv, rv); {
+		case !ok:
+			panic(fmt.Sprintf("%T holds a value that is not comparable", v))
+		case !eq:
+			panic(fmt.Sprintf("NaN sentinel can never match: %v", v))
+		}
+		sf[t] = append(sf[t], rv)
+	}
+	return cmp.FilterPath(sf.filter, cmp.Ignore())
+}
+
+type sentinelFilter map[reflect.Type][]reflect.Value
+
+func (sf sentinelFilter) filter(p cmp.Path) bool {
+	vx, vy := p.Last().Values()
+	return sf.isSentinel(vx) && sf.isSentinel(vy)
+}
+func (sf sentinelFilter) isSentinel(v reflect.Value) bool {
+	if v.IsValid() && v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
 		return false
 	}
-	return xf.m[p.Index(-2).Type()] && !isExported(sf.Name())
+	for _, s := range sf[v.Type()] {
+		if eq, _ := compareValues(v, s); eq {
+			return true
+		}
+	}
+	return false
+}
+
+// compareValues reports whether x == y. It reports ok as false if the values
+// cannot be compared (e.g., an interface field holding a slice).
+func compareValues(x, y reflect.Value) (eq, ok bool) {
+	defer func() {
+		if recover() != nil {
+			eq, ok = false, false
+		}
+	}()
+	return x.Equal(y), true
 }
 
 // isExported reports whether the identifier is exported.
@@ -153,6 +468,24 @@ func isExported(id string) bool {
 // ignore slice elements of type V, where V is assignable to T.
 // Elements are ignored if the function reports true.
 func IgnoreSliceElements(discardFunc interface{}) cmp.Option {
+	return ignoreSliceElements(discardFunc, true, true)
+}
+
+// IgnoreSliceElementsInX is like [IgnoreSliceElements], but the discard
+// function is only called on elements of the slice from the x value.
+// Elements of the slice from the y value are never ignored by this option.
+func IgnoreSliceElementsInX(discardFunc interface{}) cmp.Option {
+	return ignoreSliceElements(discardFunc, true, false)
+}
+
+// IgnoreSliceElementsInY is like [IgnoreSliceElements], but the discard
+// function is only called on elements of the slice from the y value.
+// Elements of the slice from the x value are never ignored by this option.
+func IgnoreSliceElementsInY(discardFunc interface{}) cmp.Option {
+	return ignoreSliceElements(discardFunc, false, true)
+}
+
+func ignoreSliceElements(discardFunc interface{}, inX, inY bool) cmp.Option {
 	vf := reflect.ValueOf(discardFunc)
 	if !function.IsType(vf.Type(), function.ValuePredicate) || vf.IsNil() {
 		panic(fmt.Sprintf("invalid discard function: %T", discardFunc))
@@ -166,10 +499,10 @@ func IgnoreSliceElements(discardFunc interface{}) cmp.Option {
 			return false
 		}
 		vx, vy := si.Values()
-		if vx.IsValid() && vf.Call([]reflect.Value{vx})[0].Bool() {
+		if inX && vx.IsValid() && vf.Call([]reflect.Value{vx})[0].Bool() {
 			return true
 		}
-		if vy.IsValid() && vf.Call([]reflect.Value{vy})[0].Bool() {
+		if inY && vy.IsValid() && vf.Call([]reflect.Value{vy})[0].Bool() {
 			return true
 		}
 		return false
@@ -204,3 +537,24 @@ func IgnoreMapEntries(discardFunc interface{}) cmp.Option {
 		return false
 	}, cmp.Ignore())
 }
+
+// MapSubset returns an [cmp.Option] that ignores map entries whose key
+// is only present in y, such that every entry in x must have an equal
+// entry in y, but y may have additional entries.
+// Entries whose key is only present in x are still reported as differences.
+// As usual, a nil map is only equal to another nil map.
+//
+// This option is asymmetric, where x is usually the expected subset and
+// y is the actual map. It applies to maps at any depth within the values.
+func MapSubset() cmp.Option {
+	return cmp.FilterPath(isOnlyInY, cmp.Ignore())
+}
+
+func isOnlyInY(p cmp.Path) bool {
+	mi, ok := p.Index(-1).(cmp.MapIndex)
+	if !ok {
+		return false
+	}
+	vx, vy := mi.Values()
+	return !vx.IsValid() && vy.IsValid()
+}
@@ -73,6 +73,18 @@ func (ss sliceSorter) sort(x interface{}) interface{} {
 func (ss sliceSorter) checkSort(v reflect.Value) {
 	start := -1 // Start of a sequence of equal elements.
 	for i := 1; i < v.Len(); i++ {
+		// Check that the sorted output is consistent with the less function.
+		// An inconsistency can only arise if the function is not transitive.
+		if ss.less(v, i, i-1) {
+			panic(fmt.Sprintf("non-transitive less or compare function detected: "+
+				"want %v to be not less than %v after sorting", v.Index(i), v.Index(i-1)))
+		}
+		if i >= 2 && ss.less(v, i-2, i-1) && ss.less(v, i-1, i) && !ss.less(v, i-2, i) {
+			panic(fmt.Sprintf("non-transitive less or compare function detected: "+
+				"%v is less than %v and %v is less than %v, but %v is not less than %v",
+				v.Index(i-2), v.Index(i-1), v.Index(i-1), v.Index(i), v.Index(i-2), v.Index(i)))
+		}
+
 		if ss.less(v, i-1, i) {
 			// Check that first and last elements in v[start:i] are equal.
 			if start >= 0 && (ss.less(v, start, i-1) || ss.less(v, i-1, start)) {
@@ -28,7 +28,7 @@ func filterField(typ interface{}, name string, opt cmp.Option) cmp.Option {
 }
 
 type structFilter struct {
-	t  reflect.Type // The root struct type to match on
+	t  reflect.Type // The root struct type to match on; nil matches any struct
 	ft fieldTree    // Tree of fields to match on
 }
 
@@ -41,14 +41,21 @@ func newStructFilter(typ interface{}, names ...string) structFilter {
 	// the transformed fields.
 
 	t := reflect.TypeOf(typ)
-	if t == nil || t.Kind() != reflect.Struct {
+	if typ != nil && t.Kind() != reflect.Struct {
 		panic(fmt.Sprintf("%T must be a non-pointer struct", typ))
 	}
 	var ft fieldTree
 	for _, name := range names {
-		cname, err := canonicalName(t, name)
+		var cname []string
+		var err error
+		if t == nil {
+			cname, err = splitName(name)
+		} else {
+			cname, err = canonicalName(t, name)
+		}
 		if err != nil {
-			panic(fmt.Sprintf("%s: %v", strings.Join(cname, "."), err))
+			name := strings.ReplaceAll(strings.Join(cname, "."), ".[*]", "[*]")
+			panic(fmt.Sprintf("%s: %v", name, err))
 		}
 		ft.insert(cname)
 	}
@@ -57,7 +64,11 @@ func newStructFilter(typ interface{}, names ...string) structFilter {
 
 func (sf structFilter) filter(p cmp.Path) bool {
 	for i, ps := range p {
-		if ps.Type().AssignableTo(sf.t) && sf.ft.matchPrefix(p[i+1:]) {
+		ok := ps.Type().Kind() == reflect.Struct
+		if sf.t != nil {
+			ok = ps.Type().AssignableTo(sf.t)
+		}
+		if ok && sf.ft.matchPrefix(p[i+1:]) {
 			return true
 		}
 	}
@@ -108,26 +119,66 @@ func (ft *fieldTree) insert(cname []string) {
 }
 
 // matchPrefix reports whether any selector in the fieldTree matches
-// the start of path p.
+// the start of path p. A "[*]" selector matches any slice, array, or map index.
 func (ft fieldTree) matchPrefix(p cmp.Path) bool {
 	for _, ps := range p {
+		var name string
 		switch ps := ps.(type) {
 		case cmp.StructField:
-			ft = ft.sub[ps.Name()]
-			if ft.ok {
-				return true
-			}
-			if len(ft.sub) == 0 {
-				return false
-			}
+			name = ps.Name()
+		case cmp.SliceIndex, cmp.MapIndex:
+			name = "[*]"
 		case cmp.Indirect:
+			continue
 		default:
 			return false
 		}
+		ft = ft.sub[name]
+		if ft.ok {
+			return true
+		}
+		if len(ft.sub) == 0 {
+			return false
+		}
 	}
 	return false
 }
 
+// splitName returns the list of identifiers in a dot-delimited selector
+// without resolving it against any struct type.
+// Thus, fields forwarded by struct embedding are not expanded.
+func splitName(sel string) ([]string, error) {
+	var ss []string
+	for _, s := range strings.Split(strings.TrimPrefix(sel, "."), ".") {
+		name, n, err := splitIndexes(s)
+		if err != nil {
+			return nil, err
+		}
+		ss = append(ss, name)
+		for i := 0; i < n; i++ {
+			ss = append(ss, "[*]")
+		}
+	}
+	return ss, nil
+}
+
+// splitIndexes splits an identifier with any number of "[*]" suffixes
+// (e.g., "Foo[*][*]") into the name and the number of suffixes.
+func splitIndexes(s string) (name string, n int, err error) {
+	name = s
+	if i := strings.IndexByte(s, '['); i >= 0 {
+		name, s = s[:i], s[i:]
+		n = strings.Count(s, "[*]")
+		if s != strings.Repeat("[*]", n) {
+			return name, 0, fmt.Errorf("index must be [*]")
+		}
+	}
+	if name == "" {
+		return name, 0, fmt.Errorf("name must not be empty")
+	}
+	return name, n, nil
+}
+
 // canonicalName returns a list of identifiers where any struct field access
 // through an embedded field is expanded to include the names of the embedded
 // types themselves.
@@ -151,6 +202,10 @@ func canonicalName(t reflect.Type, sel string) ([]string, error) {
 	} else {
 		name, sel = sel[:i], sel[i:]
 	}
+	name, numIndexes, err := splitIndexes(name)
+	if err != nil {
+		return []string{name}, err
+	}
 
 	// Type must be a struct or pointer to struct.
 	if t.Kind() == reflect.Ptr {
@@ -175,15 +230,30 @@ func canonicalName(t reflect.Type, sel string) ([]string, error) {
 		}
 	}
 	if sf.Name == "" {
-		return []string{name}, fmt.Errorf("does not exist")
+		return []string{name}, fmt.Errorf("does not exist in %v", t)
 	}
 	var ss []string
 	for i := range sf.Index {
 		ss = append(ss, t.FieldByIndex(sf.Index[:i+1]).Name)
 	}
+
+	// Descend into the elements for each "[*]" suffix.
+	t = sf.Type
+	for i := 0; i < numIndexes; i++ {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+		default:
+			return ss, fmt.Errorf("%v must be a slice, array, or map", t)
+		}
+		ss = append(ss, "[*]")
+		t = t.Elem()
+	}
 	if sel == "" {
 		return ss, nil
 	}
-	ssPost, err := canonicalName(sf.Type, sel)
+	ssPost, err := canonicalName(t, sel)
 	return append(ss, ssPost...), err
 }
@@ -11,6 +11,7 @@ import (
 	"io"
 	"math"
 	"net/netip"
+	"net/url"
 	"reflect"
 	"strings"
 	"sync"
@@ -67,8 +68,24 @@ type (
 	}
 
 	EmptyInterface interface{}
+
+	Ledger struct {
+		Entries []*Foo1
+		ByName  map[string]Foo1
+		Matrix  [][2]Foo1
+		Total   int
+	}
 )
 
+func mustParseURL(s string) url.URL { return *ptrURL(s) }
+func ptrURL(s string) *url.URL {
+	u, err := url.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
 func TestOptions(t *testing.T) {
 	createBar3X := func() *Bar3 {
 		return &Bar3{
@@ -117,6 +134,81 @@ func TestOptions(t *testing.T) {
 		opts:      []cmp.Option{EquateEmpty()},
 		wantEqual: true,
 		reason:    "equal because EquateEmpty equates empty slices",
+	}, {
+		label:     "EquateEmpty",
+		x:         make(chan int),
+		y:         (chan int)(nil),
+		wantEqual: false,
+		reason:    "not equal because unbuffered non-nil and nil channel differ",
+	}, {
+		label:     "EquateEmpty",
+		x:         make(chan int),
+		y:         (chan int)(nil),
+		opts:      []cmp.Option{EquateEmpty()},
+		wantEqual: true,
+		reason:    "equal because EquateEmpty equates unbuffered and nil channels",
+	}, {
+		label:     "EquateEmpty",
+		x:         make(chan int, 1),
+		y:         (chan int)(nil),
+		opts:      []cmp.Option{EquateEmpty()},
+		wantEqual: false,
+		reason:    "not equal because a buffered channel has non-zero capacity",
+	}, {
+		label:     "EquateEmpty",
+		x:         make(chan int),
+		y:         make(chan int),
+		opts:      []cmp.Option{EquateEmpty()},
+		wantEqual: false,
+		reason:    "not equal because two distinct non-nil channels are never empty-equated",
+	}, {
+		label:     "EquateEmpty",
+		x:         []interface{}{[]int(nil), map[int]int(nil)},
+		y:         []interface{}{[]int{}, map[int]int{}},
+		opts:      []cmp.Option{EquateEmpty()},
+		wantEqual: true,
+		reason:    "equal because EquateEmpty applies to the dynamic values within interfaces",
+	}, {
+		label:     "EquateEmpty",
+		x:         struct{ V interface{} }{[]int(nil)},
+		y:         struct{ V interface{} }{[]string{}},
+		opts:      []cmp.Option{EquateEmpty()},
+		wantEqual: false,
+		reason:    "not equal because the dynamic types within the interfaces differ",
+	}, {
+		label:     "EquateEmpty",
+		x:         (*MyInts)(nil),
+		y:         &MyInts{},
+		wantEqual: false,
+		reason:    "not equal because nil and non-nil pointers differ",
+	}, {
+		label:     "EquateEmpty",
+		x:         struct{ P *MyInts }{nil},
+		y:         struct{ P *MyInts }{&MyInts{}},
+		opts:      []cmp.Option{EquateEmpty()},
+		wantEqual: true,
+		reason:    "equal because EquateEmpty equates a nil pointer with a pointer to an empty slice",
+	}, {
+		label:     "EquateEmpty",
+		x:         &map[string]int{},
+		y:         (*map[string]int)(nil),
+		opts:      []cmp.Option{EquateEmpty()},
+		wantEqual: true,
+		reason:    "equal because EquateEmpty equates a nil pointer with a pointer to an empty map",
+	}, {
+		label:     "EquateEmpty",
+		x:         (*MyInts)(nil),
+		y:         &MyInts{1},
+		opts:      []cmp.Option{EquateEmpty()},
+		wantEqual: false,
+		reason:    "not equal because the pointed-at slice is not empty",
+	}, {
+		label:     "EquateEmpty",
+		x:         (*int)(nil),
+		y:         new(int),
+		opts:      []cmp.Option{EquateEmpty()},
+		wantEqual: false,
+		reason:    "not equal because EquateEmpty only applies to pointers to slices and maps",
 	}, {
 		label:     "SortSlices",
 		x:         []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
@@ -168,6 +260,15 @@ func TestOptions(t *testing.T) {
 		opts:      []cmp.Option{SortSlices(func(x, y float64) bool { return x < y })},
 		wantPanic: true,
 		reason:    "panics because SortSlices used with non-transitive less function",
+	}, {
+		label: "SortSlices",
+		x:     []int{0, 1, 2},
+		y:     []int{2, 1, 0},
+		opts: []cmp.Option{SortSlices(func(x, y int) bool {
+			return (y-x+3)%3 == 1 // 0 < 1 < 2 < 0
+		})},
+		wantPanic: true,
+		reason:    "panics because SortSlices used with a cyclic less function",
 	}, {
 		label: "SortSlices",
 		x:     []float64{0, 1, 1, 2, 2, 2, math.NaN(), 3, 3, 3, 3, 4, 4, 4, 4},
@@ -547,6 +648,41 @@ func TestOptions(t *testing.T) {
 		opts:      []cmp.Option{EquateApproxTime(3 * time.Second)},
 		wantEqual: false,
 		reason:    "time difference overflows time.Duration",
+	}, {
+		label:     "EquateApproxTime",
+		x:         time.Date(2009, 11, 10, 23, 0, 0, 0, time.UTC),
+		y:         time.Date(2009, 11, 11, 0, 0, 2, 0, time.FixedZone("UTC+1", 60*60)),
+		opts:      []cmp.Option{EquateApproxTime(3 * time.Second)},
+		wantEqual: true,
+		reason:    "equal because times in different locations are compared as instants",
+	}, {
+		label:     "EquateDurations",
+		x:         []time.Duration{time.Second, 5 * time.Millisecond},
+		y:         []time.Duration{time.Second + time.Millisecond, 4 * time.Millisecond},
+		opts:      []cmp.Option{EquateDurations(time.Millisecond)},
+		wantEqual: true,
+		reason:    "equal because the durations are within a millisecond",
+	}, {
+		label:     "EquateDurations",
+		x:         struct{ D time.Duration }{time.Second},
+		y:         struct{ D time.Duration }{time.Second + time.Millisecond + 1},
+		opts:      []cmp.Option{EquateDurations(time.Millisecond)},
+		wantEqual: false,
+		reason:    "not equal because the durations are not within a millisecond",
+	}, {
+		label:     "EquateDurations",
+		x:         time.Duration(math.MinInt64),
+		y:         time.Duration(math.MaxInt64),
+		opts:      []cmp.Option{EquateDurations(time.Duration(math.MaxInt64))},
+		wantEqual: false,
+		reason:    "not equal because the difference overflows time.Duration",
+	}, {
+		label:     "EquateDurations",
+		x:         time.Duration(-1),
+		y:         time.Duration(math.MaxInt64 - 1),
+		opts:      []cmp.Option{EquateDurations(time.Duration(math.MaxInt64))},
+		wantEqual: true,
+		reason:    "equal because the difference is exactly the margin",
 	}, {
 		label:     "EquateErrors",
 		x:         nil,
@@ -709,6 +845,33 @@ func TestOptions(t *testing.T) {
 		opts:      []cmp.Option{EquateErrors()},
 		wantEqual: false,
 		reason:    "AnyError is not equal to nil value",
+	}, {
+		label:     "EquateFuncs",
+		x:         struct{ F func(string) string }{strings.ToUpper},
+		y:         struct{ F func(string) string }{strings.ToUpper},
+		opts:      []cmp.Option{EquateFuncs()},
+		wantEqual: true,
+		reason:    "equal because both fields refer to the same function",
+	}, {
+		label:     "EquateFuncs",
+		x:         struct{ F func(string) string }{strings.ToUpper},
+		y:         struct{ F func(string) string }{strings.ToLower},
+		opts:      []cmp.Option{EquateFuncs()},
+		wantEqual: false,
+		reason:    "not equal because the fields refer to different functions",
+	}, {
+		label:     "EquateFuncs",
+		x:         struct{ F func(string) string }{nil},
+		y:         struct{ F func(string) string }{strings.ToUpper},
+		opts:      []cmp.Option{EquateFuncs()},
+		wantEqual: false,
+		reason:    "not equal because only one function is nil",
+	}, {
+		label:     "EquateFuncs",
+		x:         struct{ F func(string) string }{strings.ToUpper},
+		y:         struct{ F func(string) string }{strings.ToUpper},
+		wantEqual: false,
+		reason:    "not equal because non-nil functions are never equal without EquateFuncs",
 	}, {
 		label: "EquateComparable",
 		x: []struct{ P netip.Addr }{
@@ -739,6 +902,251 @@ func TestOptions(t *testing.T) {
 		opts:      []cmp.Option{EquateComparable(netip.Addr{})},
 		wantEqual: false,
 		reason:    "not equal because second IP address is different",
+	}, {
+		label:     "EquateComparable",
+		x:         []struct{ A, b int }{{1, 2}, {3, 4}},
+		y:         []struct{ A, b int }{{1, 2}, {3, 4}},
+		opts:      []cmp.Option{EquateComparable()},
+		wantEqual: true,
+		reason:    "equal because structs with unexported fields are compared with ==",
+	}, {
+		label:     "EquateComparable",
+		x:         []struct{ A, b int }{{1, 2}, {3, 4}},
+		y:         []struct{ A, b int }{{1, 2}, {3, 5}},
+		opts:      []cmp.Option{EquateComparable()},
+		wantEqual: false,
+		reason:    "not equal because the unexported field differs",
+	}, {
+		label:     "EquateComparable",
+		x:         struct{ P *int }{new(int)},
+		y:         struct{ P *int }{new(int)},
+		opts:      []cmp.Option{EquateComparable()},
+		wantEqual: true,
+		reason:    "equal because pointers are never compared with == and are still followed",
+	}, {
+		label:     "EquateComparable",
+		x:         struct{ b *int }{new(int)},
+		y:         struct{ b *int }{new(int)},
+		opts:      []cmp.Option{EquateComparable()},
+		wantPanic: true,
+		reason:    "panic because structs containing pointers are not compared with ==",
+	}, {
+		label:     "EquateComparable",
+		x:         MyTime{time.Unix(0, 0).In(time.UTC)},
+		y:         MyTime{time.Unix(0, 0).In(time.FixedZone("", 0))},
+		opts:      []cmp.Option{EquateComparable()},
+		wantEqual: true,
+		reason:    "equal because types with an Equal method are not compared with ==",
+	}, {
+		label:     "EquateUnorderedSlices",
+		x:         []string{"a", "b", "a", "c"},
+		y:         []string{"c", "a", "b", "a"},
+		opts:      []cmp.Option{EquateUnorderedSlices("")},
+		wantEqual: true,
+		reason:    "equal because the slices contain the same elements",
+	}, {
+		label:     "EquateUnorderedSlices",
+		x:         []string{"a", "b", "a"},
+		y:         []string{"a", "b", "b"},
+		opts:      []cmp.Option{EquateUnorderedSlices("")},
+		wantEqual: false,
+		reason:    "not equal because the elements occur a different number of times",
+	}, {
+		label:     "EquateUnorderedSlices",
+		x:         []MyInt{1, 2, 3},
+		y:         []MyInt{3, 2, 1},
+		opts:      []cmp.Option{EquateUnorderedSlices(0)},
+		wantEqual: false,
+		reason:    "not equal because MyInt is not the specified element type",
+	}, {
+		label:     "EquateUnorderedSlices",
+		x:         []int(nil),
+		y:         []int{},
+		opts:      []cmp.Option{EquateUnorderedSlices(0)},
+		wantEqual: false,
+		reason:    "not equal because a nil slice is not equal to an empty slice",
+	}, {
+		label:     "EquateUnorderedSlices+EquateEmpty",
+		x:         []int(nil),
+		y:         []int{},
+		opts:      []cmp.Option{EquateUnorderedSlices(0), EquateEmpty()},
+		wantEqual: true,
+		reason:    "equal because EquateEmpty equates nil and empty slices",
+	}, {
+		label:     "EquateNilPointers",
+		x:         struct{ P *Foo1 }{nil},
+		y:         struct{ P *Foo1 }{&Foo1{}},
+		opts:      []cmp.Option{EquateNilPointers(Foo1{})},
+		wantEqual: true,
+		reason:    "equal because a nil pointer is equal to a pointer to the zero value",
+	}, {
+		label:     "EquateNilPointers",
+		x:         []*int{new(int), nil},
+		y:         []*int{nil, new(int)},
+		opts:      []cmp.Option{EquateNilPointers(0)},
+		wantEqual: true,
+		reason:    "equal because nil pointers are equal to pointers to the zero value",
+	}, {
+		label:     "EquateNilPointers",
+		x:         struct{ P *Foo1 }{nil},
+		y:         struct{ P *Foo1 }{&Foo1{Alpha: 1}},
+		opts:      []cmp.Option{EquateNilPointers(Foo1{})},
+		wantEqual: false,
+		reason:    "not equal because the non-nil pointer does not point to the zero value",
+	}, {
+		label:     "EquateNilPointers",
+		x:         struct{ P *Foo1 }{nil},
+		y:         struct{ P *Foo1 }{&Foo1{}},
+		opts:      []cmp.Option{EquateNilPointers(0)},
+		wantEqual: false,
+		reason:    "not equal because Foo1 is not one of the listed types",
+	}, {
+		label:     "EquateURLs",
+		x:         mustParseURL("http://example.com/a?q=1"),
+		y:         url.URL{Scheme: "http", Host: "example.com", Path: "/a", RawQuery: "q=1"},
+		opts:      []cmp.Option{EquateURLs()},
+		wantEqual: true,
+		reason:    "equal because the URLs have the same canonical form",
+	}, {
+		label:     "EquateURLs",
+		x:         mustParseURL("http://example.com:80/a"),
+		y:         mustParseURL("http://example.com/a"),
+		opts:      []cmp.Option{EquateURLs()},
+		wantEqual: false,
+		reason:    "not equal because default ports are kept unless DropDefaultPorts is set",
+	}, {
+		label:     "EquateURLs",
+		x:         []*url.URL{ptrURL("http://example.com:80/a"), ptrURL("https://[::1]:443/")},
+		y:         []*url.URL{ptrURL("http://example.com/a"), ptrURL("https://[::1]/")},
+		opts:      []cmp.Option{EquateURLs(URLEquateOptions{DropDefaultPorts: true})},
+		wantEqual: true,
+		reason:    "equal because default ports are dropped",
+	}, {
+		label:     "EquateURLs",
+		x:         mustParseURL("https://example.com:80/a"),
+		y:         mustParseURL("https://example.com/a"),
+		opts:      []cmp.Option{EquateURLs(URLEquateOptions{DropDefaultPorts: true})},
+		wantEqual: false,
+		reason:    "not equal because 80 is not the default port for https",
+	}, {
+		label:     "EquateURLs",
+		x:         mustParseURL("http://example.com/a/./b/../c/"),
+		y:         mustParseURL("http://example.com/a/c/"),
+		opts:      []cmp.Option{EquateURLs(URLEquateOptions{CleanPaths: true})},
+		wantEqual: true,
+		reason:    "equal because the paths are equal after cleaning",
+	}, {
+		label:     "EquateURLs",
+		x:         mustParseURL("http://example.com/a/c/"),
+		y:         mustParseURL("http://example.com/a/c"),
+		opts:      []cmp.Option{EquateURLs(URLEquateOptions{CleanPaths: true})},
+		wantEqual: false,
+		reason:    "not equal because cleaning preserves the trailing slash",
+	}, {
+		label:     "EquateURLs",
+		x:         mustParseURL("http://example.com/a/./b"),
+		y:         mustParseURL("http://example.com/a/b"),
+		opts:      []cmp.Option{EquateURLs()},
+		wantEqual: false,
+		reason:    "not equal because paths are not cleaned unless CleanPaths is set",
+	}, {
+		label:     "EquateJSON",
+		x:         struct{ B []byte }{[]byte(`{"a": 1, "b": [true, null]}`)},
+		y:         struct{ B []byte }{[]byte(`{"b":[true,null],"a":1.0}`)},
+		opts:      []cmp.Option{EquateJSON()},
+		wantEqual: true,
+		reason:    "equal because the JSON values are equivalent",
+	}, {
+		label:     "EquateJSON",
+		x:         []string{`{"a": 1}`, `{"a": "1"}`},
+		y:         []string{`{ "a" : 1 }`, `{"a": "1"}`},
+		opts:      []cmp.Option{EquateJSON()},
+		wantEqual: true,
+		reason:    "equal because the JSON values are equivalent",
+	}, {
+		label:     "EquateJSON",
+		x:         `{"a": "1"}`,
+		y:         `{"a": "1.0"}`,
+		opts:      []cmp.Option{EquateJSON()},
+		wantEqual: false,
+		reason:    "not equal because strings within decoded JSON are not decoded again",
+	}, {
+		label:     "EquateJSON",
+		x:         `{"a": 1}`,
+		y:         `{"a": 1`,
+		opts:      []cmp.Option{EquateJSON()},
+		wantEqual: false,
+		reason:    "not equal because invalid JSON is compared as a string",
+	}, {
+		label:     "EquateJSON",
+		x:         []interface{}{`{"a": 1}`},
+		y:         []interface{}{[]byte(`{"a": 1}`)},
+		opts:      []cmp.Option{EquateJSON()},
+		wantEqual: false,
+		reason:    "not equal because a string and a []byte are different types",
+	}, {
+		label:     "IgnoreFields",
+		x:         struct{ A, B int }{1, 2},
+		y:         struct{ A, B int }{1, 3},
+		opts:      []cmp.Option{IgnoreFields(nil, "B")},
+		wantEqual: true,
+		reason:    "equal because field B is ignored on an unnamed struct type",
+	}, {
+		label:     "IgnoreFields",
+		x:         []struct{ S struct{ A, B int } }{{struct{ A, B int }{1, 2}}},
+		y:         []struct{ S struct{ A, B int } }{{struct{ A, B int }{1, 3}}},
+		opts:      []cmp.Option{IgnoreFields(nil, "S.B")},
+		wantEqual: true,
+		reason:    "equal because nested field S.B is ignored on any struct type",
+	}, {
+		label:     "IgnoreFields",
+		x:         struct{ A, B int }{1, 2},
+		y:         struct{ A, B int }{0, 3},
+		opts:      []cmp.Option{IgnoreFields(nil, "B")},
+		wantEqual: false,
+		reason:    "not equal because field A is not ignored",
+	}, {
+		label:     "IgnoreFields",
+		x:         Ledger{Entries: []*Foo1{{Alpha: 1, Bravo: 2}, {Alpha: 3, Bravo: 4}}},
+		y:         Ledger{Entries: []*Foo1{{Alpha: 1, Bravo: 5}, {Alpha: 3, Bravo: 6}}},
+		opts:      []cmp.Option{IgnoreFields(Ledger{}, "Entries[*].Bravo")},
+		wantEqual: true,
+		reason:    "equal because Bravo is ignored in every element of Entries",
+	}, {
+		label:     "IgnoreFields",
+		x:         Ledger{Entries: []*Foo1{{Alpha: 1, Bravo: 2}}, Total: 1},
+		y:         Ledger{Entries: []*Foo1{{Alpha: 1, Bravo: 5}}, Total: 2},
+		opts:      []cmp.Option{IgnoreFields(Ledger{}, "Entries[*].Bravo")},
+		wantEqual: false,
+		reason:    "not equal because Total is not ignored",
+	}, {
+		label:     "IgnoreFields",
+		x:         Ledger{Entries: []*Foo1{{Alpha: 1, Bravo: 2}}},
+		y:         Ledger{Entries: []*Foo1{{Alpha: 0, Bravo: 5}}},
+		opts:      []cmp.Option{IgnoreFields(Ledger{}, "Entries[*].Bravo")},
+		wantEqual: false,
+		reason:    "not equal because Alpha is not ignored",
+	}, {
+		label:     "IgnoreFields",
+		x:         Ledger{ByName: map[string]Foo1{"a": {Alpha: 1, Charlie: 2}}, Matrix: [][2]Foo1{{{Charlie: 1}}}},
+		y:         Ledger{ByName: map[string]Foo1{"a": {Alpha: 1, Charlie: 3}}, Matrix: [][2]Foo1{{{Charlie: 2}}}},
+		opts:      []cmp.Option{IgnoreFields(Ledger{}, "ByName[*].Charlie", "Matrix[*][*].Charlie")},
+		wantEqual: true,
+		reason:    "equal because Charlie is ignored in every map entry and nested array element",
+	}, {
+		label:     "IgnoreFields",
+		x:         Ledger{Entries: []*Foo1{{Alpha: 1}}},
+		y:         Ledger{Entries: []*Foo1{{Alpha: 1}, {Alpha: 2}}},
+		opts:      []cmp.Option{IgnoreFields(Ledger{}, "Entries[*]")},
+		wantEqual: true,
+		reason:    "equal because every element of Entries is ignored",
+	}, {
+		label:     "IgnoreFields",
+		x:         []struct{ L []Foo1 }{{[]Foo1{{Alpha: 1, Bravo: 2}}}},
+		y:         []struct{ L []Foo1 }{{[]Foo1{{Alpha: 1, Bravo: 3}}}},
+		opts:      []cmp.Option{IgnoreFields(nil, "L[*].Bravo")},
+		wantEqual: true,
+		reason:    "equal because Bravo is ignored in every element of L on any struct type",
 	}, {
 		label:     "IgnoreFields",
 		x:         Bar1{Foo3{&Foo2{&Foo1{Alpha: 5}}}},
@@ -780,6 +1188,20 @@ func TestOptions(t *testing.T) {
 		opts:      []cmp.Option{IgnoreFields(Bar1{}, "Foo3.Foo2.Alpha")},
 		wantEqual: true,
 		reason:    "equal because IgnoreField ignores deeply embedded field: Foo3.Foo2.Alpha",
+	}, {
+		label:     "IgnoreFields",
+		x:         Bar3{Bravo: &Bar2{Bravo: 1}},
+		y:         Bar3{Bravo: &Bar2{Bravo: 2}},
+		opts:      []cmp.Option{IgnoreFields(Bar3{}, "Bravo.Bravo")},
+		wantEqual: true,
+		reason:    "equal because IgnoreField follows the named pointer field: Bravo.Bravo",
+	}, {
+		label:     "IgnoreFields",
+		x:         Bar3{Bravo: nil},
+		y:         Bar3{Bravo: &Bar2{Bravo: 2}},
+		opts:      []cmp.Option{IgnoreFields(Bar3{}, "Bravo.Bravo")},
+		wantEqual: false,
+		reason:    "not equal because the intermediate pointer Bravo is only nil on one side",
 	}, {
 		label:     "IgnoreFields",
 		x:         createBar3X(),
@@ -944,6 +1366,13 @@ func TestOptions(t *testing.T) {
 		opts:      []cmp.Option{IgnoreUnexported(ParentStruct{})},
 		wantEqual: true,
 		reason:    "equal because IgnoreUnexported ignored ParentStruct.private",
+	}, {
+		label:     "IgnoreUnexported",
+		x:         ParentStruct{Public: 1, private: 2},
+		y:         ParentStruct{Public: 1, private: -2},
+		opts:      []cmp.Option{IgnoreUnexported(reflect.TypeOf(ParentStruct{}))},
+		wantEqual: true,
+		reason:    "equal because IgnoreUnexported ignored ParentStruct.private specified by reflect.Type",
 	}, {
 		label: "IgnoreUnexported",
 		x:     ParentStruct{Public: 1, private: 2, PublicStruct: &PublicStruct{Public: 3, private: 4}},
@@ -1012,6 +1441,320 @@ func TestOptions(t *testing.T) {
 		},
 		wantEqual: false,
 		reason:    "not equal because privateStruct.Public differs and not ignored by IgnoreUnexported(privateStruct{})",
+	}, {
+		label: "IgnoreAllUnexported",
+		x:     ParentStruct{Public: 1, private: 2, PublicStruct: &PublicStruct{Public: 3, private: 4}},
+		y:     ParentStruct{Public: 1, private: -2, PublicStruct: &PublicStruct{Public: 3, private: -4}},
+		opts: []cmp.Option{
+			IgnoreAllUnexported(),
+		},
+		wantEqual: true,
+		reason:    "equal because unexported fields are ignored at every level",
+	}, {
+		label: "IgnoreAllUnexported",
+		x:     ParentStruct{Public: 1, private: 2, PublicStruct: &PublicStruct{Public: 3, private: 4}},
+		y:     ParentStruct{Public: 1, private: -2, PublicStruct: &PublicStruct{Public: -3, private: -4}},
+		opts: []cmp.Option{
+			IgnoreAllUnexported(),
+		},
+		wantEqual: false,
+		reason:    "not equal because ParentStruct.PublicStruct.Public differs",
+	}, {
+		label: "IgnoreAllUnexported",
+		x:     ParentStruct{Public: 1, privateStruct: &privateStruct{Public: 3, private: 4}},
+		y:     ParentStruct{Public: 1, privateStruct: &privateStruct{Public: -3, private: -4}},
+		opts: []cmp.Option{
+			IgnoreAllUnexported(),
+		},
+		wantEqual: true,
+		reason:    "equal because the embedded privateStruct field is itself unexported",
+	}, {
+		label: "IgnoreAllUnexported",
+		x:     []struct{ A, b int }{{1, 2}, {3, 4}},
+		y:     []struct{ A, b int }{{1, -2}, {3, -4}},
+		opts: []cmp.Option{
+			IgnoreAllUnexported(),
+		},
+		wantEqual: true,
+		reason:    "equal because unexported fields of unnamed struct types are also ignored",
+	}, {
+		label: "IgnoreUnexportedInPkg",
+		x:     ParentStruct{Public: 1, private: 2, PublicStruct: &PublicStruct{Public: 3, private: 4}},
+		y:     ParentStruct{Public: 1, private: -2, PublicStruct: &PublicStruct{Public: 3, private: -4}},
+		opts: []cmp.Option{
+			IgnoreUnexportedInPkg("github.com/google/go-cmp/cmp/cmpopts"),
+		},
+		wantEqual: true,
+		reason:    "equal because all unexported fields are declared in the cmpopts package",
+	}, {
+		label: "IgnoreUnexportedInPkg",
+		x:     ParentStruct{Public: 1, private: 2},
+		y:     ParentStruct{Public: 1, private: -2},
+		opts: []cmp.Option{
+			IgnoreUnexportedInPkg("github.com/google/go-cmp/cmp"),
+		},
+		wantPanic: true,
+		reason:    "panic because the package path must match exactly and not as a prefix",
+	}, {
+		label: "IgnoreUnexportedInModule",
+		x:     ParentStruct{Public: 1, private: 2, PublicStruct: &PublicStruct{Public: 3, private: 4}},
+		y:     ParentStruct{Public: 1, private: -2, PublicStruct: &PublicStruct{Public: 3, private: -4}},
+		opts: []cmp.Option{
+			IgnoreUnexportedInModule("github.com/google/go-cmp"),
+		},
+		wantEqual: true,
+		reason:    "equal because the cmpopts package is within the module",
+	}, {
+		label: "IgnoreUnexportedInModule",
+		x:     ParentStruct{Public: 1, private: 2},
+		y:     ParentStruct{Public: 1, private: -2},
+		opts: []cmp.Option{
+			IgnoreUnexportedInModule("github.com/google/go-cmp/cmp/cmp"),
+		},
+		wantPanic: true,
+		reason:    "panic because the module path must match whole path elements",
+	}, {
+		label: "IgnoreFieldsByTag",
+		x: []struct {
+			A int
+			B int `json:"-"`
+		}{{1, 2}, {3, 4}},
+		y: []struct {
+			A int
+			B int `json:"-"`
+		}{{1, -2}, {3, -4}},
+		opts: []cmp.Option{
+			IgnoreFieldsByTag("json", "-"),
+		},
+		wantEqual: true,
+		reason:    "equal because fields tagged with json:\"-\" are ignored",
+	}, {
+		label: "IgnoreFieldsByTag",
+		x: struct {
+			A int `json:"a"`
+			B int `db:"-"`
+		}{1, 2},
+		y: struct {
+			A int `json:"a"`
+			B int `db:"-"`
+		}{-1, 2},
+		opts: []cmp.Option{
+			IgnoreFieldsByTag("db", "-"),
+		},
+		wantEqual: false,
+		reason:    "not equal because A is not tagged with db:\"-\"",
+	}, {
+		label: "IgnoreFieldsByTag",
+		x: struct {
+			A int `json:"a,omitempty"`
+		}{1},
+		y: struct {
+			A int `json:"a,omitempty"`
+		}{-1},
+		opts: []cmp.Option{
+			IgnoreFieldsByTag("json", "a"),
+		},
+		wantEqual: false,
+		reason:    "not equal because the tag value must match exactly",
+	}, {
+		label: "IgnoreFieldsInTypes",
+		x: []interface{}{
+			struct{ A, CreatedAt int }{1, 2},
+			&struct{ B, CreatedAt int }{1, 2},
+		},
+		y: []interface{}{
+			struct{ A, CreatedAt int }{1, 3},
+			&struct{ B, CreatedAt int }{1, 4},
+		},
+		opts: []cmp.Option{IgnoreFieldsInTypes([]string{"CreatedAt"},
+			struct{ A, CreatedAt int }{}, struct{ B, CreatedAt int }{})},
+		wantEqual: true,
+		reason:    "equal because CreatedAt is ignored in both struct types",
+	}, {
+		label: "IgnoreFieldsInTypes",
+		x: []interface{}{
+			struct{ A, CreatedAt int }{1, 2},
+			struct{ B, CreatedAt int }{1, 2},
+		},
+		y: []interface{}{
+			struct{ A, CreatedAt int }{1, 3},
+			struct{ B, CreatedAt int }{1, 4},
+		},
+		opts: []cmp.Option{IgnoreFieldsInTypes([]string{"CreatedAt"},
+			struct{ A, CreatedAt int }{})},
+		wantEqual: false,
+		reason:    "not equal because CreatedAt is not ignored in unlisted struct types",
+	}, {
+		label: "IgnoreFieldsByPrefix",
+		x:     struct{ Name, CacheKey, CacheHash string }{"a", "b", "c"},
+		y:     struct{ Name, CacheKey, CacheHash string }{"a", "x", "y"},
+		opts: []cmp.Option{
+			IgnoreFieldsByPrefix(struct{ Name, CacheKey, CacheHash string }{}, "Cache"),
+		},
+		wantEqual: true,
+		reason:    "equal because all fields prefixed with Cache are ignored",
+	}, {
+		label: "IgnoreFieldsByPrefix",
+		x:     struct{ Name, CacheKey, CacheHash string }{"a", "b", "c"},
+		y:     struct{ Name, CacheKey, CacheHash string }{"x", "b", "c"},
+		opts: []cmp.Option{
+			IgnoreFieldsByPrefix(struct{ Name, CacheKey, CacheHash string }{}, "Cache", "ID"),
+		},
+		wantEqual: false,
+		reason:    "not equal because Name does not have any of the prefixes",
+	}, {
+		label: "IgnoreFieldsByPrefix",
+		x:     ParentStruct{Public: 1, PublicStruct: &PublicStruct{Public: 2}},
+		y:     ParentStruct{Public: 1, PublicStruct: &PublicStruct{Public: 3}},
+		opts: []cmp.Option{
+			IgnoreFieldsByPrefix(ParentStruct{}, "Pub", "priv"),
+		},
+		wantEqual: true,
+		reason:    "equal because every field of ParentStruct has one of the prefixes",
+	}, {
+		label: "IgnoreFieldsByPrefix",
+		x:     ParentStruct{Public: 1, PublicStruct: &PublicStruct{Public: 2}},
+		y:     ParentStruct{Public: 1, PublicStruct: &PublicStruct{Public: 3}},
+		opts: []cmp.Option{
+			cmp.AllowUnexported(ParentStruct{}, PublicStruct{}),
+			IgnoreFieldsByPrefix(PublicStruct{}, "priv"),
+		},
+		wantEqual: false,
+		reason:    "not equal because only fields immediately within PublicStruct are considered",
+	}, {
+		label: "IgnoreZeroFields",
+		x:     ParentStruct{Public: 1, PublicStruct: &PublicStruct{Public: 2}},
+		y:     ParentStruct{Public: 1, PublicStruct: &PublicStruct{Public: 2}},
+		opts: []cmp.Option{
+			IgnoreZeroFields(ParentStruct{}, PublicStruct{}),
+		},
+		wantEqual: true,
+		reason:    "equal because unexported fields that are zero on both sides are ignored",
+	}, {
+		label: "IgnoreZeroFields",
+		x:     ParentStruct{Public: 1, PublicStruct: &PublicStruct{Public: 2}},
+		y:     ParentStruct{Public: 1, PublicStruct: &PublicStruct{Public: 2}},
+		opts: []cmp.Option{
+			IgnoreZeroFields(ParentStruct{}),
+		},
+		wantPanic: true,
+		reason:    "panic because PublicStruct is not listed and has unexported fields",
+	}, {
+		label: "IgnoreZeroFields",
+		x:     ParentStruct{Public: 1, private: 2},
+		y:     ParentStruct{Public: 1},
+		opts: []cmp.Option{
+			IgnoreZeroFields(ParentStruct{}),
+		},
+		wantPanic: true,
+		reason:    "panic because ParentStruct.private is non-zero on one side",
+	}, {
+		label: "IgnoreZeroFields",
+		x:     ParentStruct{Public: 0},
+		y:     ParentStruct{Public: 1},
+		opts: []cmp.Option{
+			IgnoreZeroFields(ParentStruct{}),
+		},
+		wantEqual: false,
+		reason:    "not equal because ParentStruct.Public is non-zero on one side",
+	}, {
+		label:     "IgnoreEmpty",
+		x:         MyStruct{A: []int{}, C: map[time.Time]string{}},
+		y:         MyStruct{B: []int{}, D: map[time.Time]string{}},
+		opts:      []cmp.Option{IgnoreEmpty(MyStruct{})},
+		wantEqual: true,
+		reason:    "equal because nil and empty collections are both empty",
+	}, {
+		label:     "IgnoreEmpty",
+		x:         MyStruct{A: []int{}, C: map[time.Time]string{}},
+		y:         MyStruct{B: []int{}, D: map[time.Time]string{}},
+		opts:      []cmp.Option{IgnoreZeroFields(MyStruct{})},
+		wantEqual: false,
+		reason:    "not equal because IgnoreZeroFields does not treat empty collections as zero",
+	}, {
+		label:     "IgnoreEmpty",
+		x:         MyStruct{A: []int{}},
+		y:         MyStruct{A: []int{1}},
+		opts:      []cmp.Option{IgnoreEmpty(MyStruct{})},
+		wantEqual: false,
+		reason:    "not equal because MyStruct.A is non-empty on one side",
+	}, {
+		label:     "IgnoreEmpty",
+		x:         ParentStruct{Public: 1, private: 0},
+		y:         ParentStruct{Public: 1},
+		opts:      []cmp.Option{IgnoreEmpty(ParentStruct{})},
+		wantEqual: true,
+		reason:    "equal because unexported fields that are empty on both sides are ignored",
+	}, {
+		label:     "IgnoreFieldsNotIn",
+		x:         ParentStruct{Public: 1, private: 2},
+		y:         ParentStruct{Public: 1, private: 3, PublicStruct: &PublicStruct{}},
+		opts:      []cmp.Option{IgnoreFieldsNotIn(ParentStruct{Public: -1})},
+		wantEqual: true,
+		reason:    "equal because only ParentStruct.Public is set in the template",
+	}, {
+		label:     "IgnoreFieldsNotIn",
+		x:         ParentStruct{Public: 1},
+		y:         ParentStruct{Public: 2},
+		opts:      []cmp.Option{IgnoreFieldsNotIn(ParentStruct{Public: -1})},
+		wantEqual: false,
+		reason:    "not equal because ParentStruct.Public differs and is set in the template",
+	}, {
+		label:     "OnlyCompareFields",
+		x:         Foo1{Alpha: 1, Bravo: 2, Charlie: 3},
+		y:         Foo1{Alpha: 1, Bravo: 5, Charlie: 6},
+		opts:      []cmp.Option{OnlyCompareFields(Foo1{}, "Alpha")},
+		wantEqual: true,
+		reason:    "equal because only Foo1.Alpha is compared",
+	}, {
+		label:     "OnlyCompareFields",
+		x:         Foo1{Alpha: 1, Bravo: 2, Charlie: 3},
+		y:         Foo1{Alpha: 1, Bravo: 5, Charlie: 3},
+		opts:      []cmp.Option{OnlyCompareFields(Foo1{}, "Alpha", "Bravo")},
+		wantEqual: false,
+		reason:    "not equal because Foo1.Bravo differs and is compared",
+	}, {
+		label:     "OnlyCompareFields",
+		x:         ParentStruct{Public: 1, private: 2},
+		y:         ParentStruct{Public: 1, private: 3, PublicStruct: &PublicStruct{}},
+		opts:      []cmp.Option{OnlyCompareFields(ParentStruct{}, "Public")},
+		wantEqual: true,
+		reason:    "equal because unexported and embedded fields are ignored",
+	}, {
+		label:     "IgnoreByValue",
+		x:         struct{ ID, N int }{-1, 1},
+		y:         struct{ ID, N int }{0, 1},
+		opts:      []cmp.Option{IgnoreByValue(-1, 0)},
+		wantEqual: true,
+		reason:    "equal because ID is a sentinel value in both x and y",
+	}, {
+		label:     "IgnoreByValue",
+		x:         struct{ ID, N int }{-1, 1},
+		y:         struct{ ID, N int }{5, 1},
+		opts:      []cmp.Option{IgnoreByValue(-1, 0)},
+		wantEqual: false,
+		reason:    "not equal because ID is a sentinel value only in x",
+	}, {
+		label:     "IgnoreByValue",
+		x:         struct{ ID MyInt }{-1},
+		y:         struct{ ID MyInt }{0},
+		opts:      []cmp.Option{IgnoreByValue(-1, 0)},
+		wantEqual: false,
+		reason:    "not equal because the sentinels are of type int, rather than MyInt",
+	}, {
+		label:     "IgnoreByValue",
+		x:         struct{ V interface{} }{[]int{1}},
+		y:         struct{ V interface{} }{[]int{1}},
+		opts:      []cmp.Option{IgnoreByValue(struct{ V interface{} }{0})},
+		wantEqual: true,
+		reason:    "equal because the slices are equal, and comparing them against the sentinel does not panic",
+	}, {
+		label:     "IgnoreByValue",
+		x:         struct{ V interface{} }{[]int{1}},
+		y:         struct{ V interface{} }{[]int{2}},
+		opts:      []cmp.Option{IgnoreByValue(struct{ V interface{} }{0})},
+		wantEqual: false,
+		reason:    "not equal because the slices differ and are not sentinel values",
 	}, {
 		label: "IgnoreFields+IgnoreTypes+IgnoreUnexported",
 		x: &Everything{
@@ -1077,6 +1820,33 @@ func TestOptions(t *testing.T) {
 		},
 		wantEqual: false,
 		reason:    "not equal because ignored elements does not imply empty slice",
+	}, {
+		label: "IgnoreSliceElementsInY",
+		x:     []int{1, 2, 3},
+		y:     []int{1, -1, 2, 3, -2},
+		opts: []cmp.Option{
+			IgnoreSliceElementsInY(func(v int) bool { return v < 0 }),
+		},
+		wantEqual: true,
+		reason:    "equal because negative elements in y are ignored",
+	}, {
+		label: "IgnoreSliceElementsInY",
+		x:     []int{1, -1, 2, 3},
+		y:     []int{1, 2, 3},
+		opts: []cmp.Option{
+			IgnoreSliceElementsInY(func(v int) bool { return v < 0 }),
+		},
+		wantEqual: false,
+		reason:    "not equal because negative elements in x are not ignored",
+	}, {
+		label: "IgnoreSliceElementsInX",
+		x:     []int{1, -1, 2, 3},
+		y:     []int{1, 2, 3},
+		opts: []cmp.Option{
+			IgnoreSliceElementsInX(func(v int) bool { return v < 0 }),
+		},
+		wantEqual: true,
+		reason:    "equal because negative elements in x are ignored",
 	}, {
 		label: "IgnoreMapEntries",
 		x:     map[string]int{"one": 1, "TWO": 2, "three": 3, "FIVE": 5},
@@ -1141,6 +1911,110 @@ func TestOptions(t *testing.T) {
 		},
 		wantEqual: true,
 		reason:    "equal because acyclic transformer splits on any contiguous whitespace",
+	}, {
+		label: "AcyclicTransformer",
+		x:     "hello",
+		y:     "hello",
+		opts: []cmp.Option{
+			AcyclicTransformer("ToBytes", func(s string) []byte { return []byte(s) }),
+			AcyclicTransformer("ToString", func(b []byte) string { return string(b) }),
+		},
+		wantEqual: true,
+		reason:    "equal because mutually recursive transformers each apply only once",
+	}, {
+		label: "AcyclicTransformer",
+		x:     "hello",
+		y:     "world",
+		opts: []cmp.Option{
+			AcyclicTransformer("ToBytes", func(s string) []byte { return []byte(s) }),
+			AcyclicTransformer("ToString", func(b []byte) string { return string(b) }),
+		},
+		wantEqual: false,
+		reason:    "not equal because strings differ, but should not recurse infinitely",
+	}, {
+		label:     "TruncateSlices",
+		x:         []int{1, 2, 3, 4, 5},
+		y:         []int{1, 2, 3, 0, 0, 0},
+		opts:      []cmp.Option{TruncateSlices(3)},
+		wantEqual: true,
+		reason:    "equal because only the first 3 elements are compared",
+	}, {
+		label:     "TruncateSlices",
+		x:         []int{1, 2, 3, 4, 5},
+		y:         []int{1, 0, 3, 4, 5},
+		opts:      []cmp.Option{TruncateSlices(3)},
+		wantEqual: false,
+		reason:    "not equal because the slices differ within the first 3 elements",
+	}, {
+		label:     "TruncateSlices",
+		x:         []int{1, 2},
+		y:         []int{1, 2, 3},
+		opts:      []cmp.Option{TruncateSlices(3)},
+		wantEqual: false,
+		reason:    "not equal because a slice shorter than n is not padded",
+	}, {
+		label:     "TruncateSlices",
+		x:         struct{ A []string }{[]string{"a", "b"}},
+		y:         struct{ A []string }{[]string{"a", "c"}},
+		opts:      []cmp.Option{TruncateSlices(0)},
+		wantEqual: true,
+		reason:    "equal because no elements are compared",
+	}, {
+		label:     "MapSubset",
+		x:         map[string]int{"a": 1},
+		y:         map[string]int{"a": 1, "b": 2},
+		opts:      []cmp.Option{MapSubset()},
+		wantEqual: true,
+		reason:    "equal because x is a subset of y",
+	}, {
+		label:     "MapSubset",
+		x:         map[string]int{"a": 1, "b": 2},
+		y:         map[string]int{"a": 1},
+		opts:      []cmp.Option{MapSubset()},
+		wantEqual: false,
+		reason:    "not equal because x has an entry missing from y",
+	}, {
+		label:     "MapSubset",
+		x:         map[string]int{"a": 1},
+		y:         map[string]int{"a": 2, "b": 2},
+		opts:      []cmp.Option{MapSubset()},
+		wantEqual: false,
+		reason:    "not equal because the entry for a common key differs",
+	}, {
+		label:     "MapSubset",
+		x:         []map[string]int{{"a": 1}, {}},
+		y:         []map[string]int{{"a": 1, "b": 2}, {"c": 3}},
+		opts:      []cmp.Option{MapSubset()},
+		wantEqual: true,
+		reason:    "equal because the option applies to nested maps",
+	}, {
+		label:     "NormalizeStrings",
+		x:         []string{"Hello", " world "},
+		y:         []string{"hello", "WORLD"},
+		opts:      []cmp.Option{NormalizeStrings(func(s string) string { return strings.ToLower(strings.TrimSpace(s)) })},
+		wantEqual: true,
+		reason:    "equal because strings are equal after normalization",
+	}, {
+		label:     "NormalizeStrings",
+		x:         struct{ A MyString }{"Hello"},
+		y:         struct{ A MyString }{"HELLO"},
+		opts:      []cmp.Option{NormalizeStrings(strings.ToLower)},
+		wantEqual: true,
+		reason:    "equal because the option applies to named string types",
+	}, {
+		label:     "NormalizeStrings",
+		x:         map[string]string{"a": "Hello"},
+		y:         map[string]string{"a": "World"},
+		opts:      []cmp.Option{NormalizeStrings(strings.ToLower)},
+		wantEqual: false,
+		reason:    "not equal because strings differ after normalization",
+	}, {
+		label:     "NormalizeStrings",
+		x:         "a",
+		y:         "a",
+		opts:      []cmp.Option{NormalizeStrings(func(s string) string { return s + s })},
+		wantEqual: true,
+		reason:    "equal because the normalization is only applied once",
 	}}
 
 	for _, tt := range tests {
@@ -1178,6 +2052,12 @@ func TestPanic(t *testing.T) {
 		wantPanic string        // Expected panic message
 		reason    string        // The reason for the expected outcome
 	}{{
+		label:     "EquateNilPointers",
+		fnc:       EquateNilPointers,
+		args:      args(nil),
+		wantPanic: "cannot determine type of nil interface value",
+		reason:    "the type of a nil interface value cannot be determined",
+	}, {
 		label:  "EquateApprox",
 		fnc:    EquateApprox,
 		args:   args(0.0, 0.0),
@@ -1216,6 +2096,24 @@ func TestPanic(t *testing.T) {
 		args:      args(time.Duration(-1)),
 		wantPanic: "margin must be a non-negative number",
 		reason:    "negative duration is invalid",
+	}, {
+		label:     "EquateDurations",
+		fnc:       EquateDurations,
+		args:      args(time.Duration(-1)),
+		wantPanic: "margin must be a non-negative number",
+		reason:    "negative duration is invalid",
+	}, {
+		label:     "EquateURLs",
+		fnc:       EquateURLs,
+		args:      args(URLEquateOptions{}, URLEquateOptions{CleanPaths: true}),
+		wantPanic: "at most one URLEquateOptions",
+		reason:    "multiple URLEquateOptions are ambiguous",
+	}, {
+		label:     "EquateUnorderedSlices",
+		fnc:       EquateUnorderedSlices,
+		args:      args([]int(nil)),
+		wantPanic: "is not a comparable Go type",
+		reason:    "slices are not comparable",
 	}, {
 		label:     "SortSlices",
 		fnc:       SortSlices,
@@ -1234,6 +2132,17 @@ func TestPanic(t *testing.T) {
 		args:      args(Foo1{}, ""),
 		wantPanic: "name must not be empty",
 		reason:    "empty selector is invalid",
+	}, {
+		label:     "IgnoreFields",
+		fnc:       IgnoreFields,
+		args:      args(nil, "Alpha..Bravo"),
+		wantPanic: "name must not be empty",
+		reason:    "empty identifier is invalid even without a struct type",
+	}, {
+		label:  "IgnoreFields",
+		fnc:    IgnoreFields,
+		args:   args(nil, "Alpha.Bravo"),
+		reason: "nil struct type matches any struct",
 	}, {
 		label:     "IgnoreFields",
 		fnc:       IgnoreFields,
@@ -1261,8 +2170,32 @@ func TestPanic(t *testing.T) {
 		label:     "IgnoreFields",
 		fnc:       IgnoreFields,
 		args:      args(Foo1{}, "Zulu"),
-		wantPanic: "does not exist",
+		wantPanic: "Zulu: does not exist in cmpopts.Foo1",
 		reason:    "name of non-existent field is invalid",
+	}, {
+		label:     "IgnoreFields",
+		fnc:       IgnoreFields,
+		args:      args(Foo1{}, "Alpha[*]"),
+		wantPanic: "Alpha: int must be a slice, array, or map",
+		reason:    "index steps are only valid on a slice, array, or map",
+	}, {
+		label:     "IgnoreFields",
+		fnc:       IgnoreFields,
+		args:      args(Ledger{}, "Entries[0].Alpha"),
+		wantPanic: "index must be [*]",
+		reason:    "only wildcard index steps are supported",
+	}, {
+		label:     "IgnoreFields",
+		fnc:       IgnoreFields,
+		args:      args(Ledger{}, "[*].Alpha"),
+		wantPanic: "name must not be empty",
+		reason:    "an index step must follow a field name",
+	}, {
+		label:     "IgnoreFields",
+		fnc:       IgnoreFields,
+		args:      args(Ledger{}, "Entries[*].Zulu"),
+		wantPanic: "Entries[*].Zulu: does not exist in cmpopts.Foo1",
+		reason:    "fields within elements are validated against the element type",
 	}, {
 		label:     "IgnoreFields",
 		fnc:       IgnoreFields,
@@ -1291,6 +2224,46 @@ func TestPanic(t *testing.T) {
 		args:      args(struct{ privateStruct }{}, "private"),
 		wantPanic: "does not exist",
 		reason:    "private field not permitted since it is a forwarded field that is unexported",
+	}, {
+		label:  "IgnoreFieldsByPrefix",
+		fnc:    IgnoreFieldsByPrefix,
+		args:   args(Foo1{}, "Al"),
+		reason: "prefix need not match any field",
+	}, {
+		label:     "IgnoreFieldsByPrefix",
+		fnc:       IgnoreFieldsByPrefix,
+		args:      args(&Foo1{}, "Al"),
+		wantPanic: "must be a non-pointer struct",
+		reason:    "the type must be a struct (not pointer to a struct)",
+	}, {
+		label:     "IgnoreFieldsInTypes",
+		fnc:       IgnoreFieldsInTypes,
+		args:      args([]string{"Alpha"}, Foo1{}, struct{ X int }{}),
+		wantPanic: "does not exist",
+		reason:    "every name must exist in every type",
+	}, {
+		label:  "IgnoreFieldsInTypes",
+		fnc:    IgnoreFieldsInTypes,
+		args:   args([]string{"Alpha"}, Foo1{}, Foo3{}),
+		reason: "Alpha is a field in both Foo1 and Foo3",
+	}, {
+		label:     "IgnoreFieldsByPrefix",
+		fnc:       IgnoreFieldsByPrefix,
+		args:      args(nil, "Al"),
+		wantPanic: "must be a non-pointer struct",
+		reason:    "nil value is not valid",
+	}, {
+		label:     "IgnoreUnexportedInPkg",
+		fnc:       IgnoreUnexportedInPkg,
+		args:      args(""),
+		wantPanic: "package path must not be empty",
+		reason:    "empty package path is invalid",
+	}, {
+		label:     "IgnoreUnexportedInModule",
+		fnc:       IgnoreUnexportedInModule,
+		args:      args(""),
+		wantPanic: "module path must not be empty",
+		reason:    "empty module path is invalid",
 	}, {
 		label:  "IgnoreTypes",
 		fnc:    IgnoreTypes,
@@ -1367,12 +2340,88 @@ func TestPanic(t *testing.T) {
 		fnc:    IgnoreUnexported,
 		args:   args(Foo1{}, struct{ x, X int }{}),
 		reason: "input may be named or unnamed structs",
+	}, {
+		label:  "IgnoreUnexported",
+		fnc:    IgnoreUnexported,
+		args:   args(reflect.TypeOf(Foo1{})),
+		reason: "input may be the reflect.Type of a struct",
+	}, {
+		label:     "IgnoreFieldsNotIn",
+		fnc:       IgnoreFieldsNotIn,
+		args:      args(&ParentStruct{}),
+		wantPanic: "must be a non-pointer struct",
+		reason:    "the template must be a struct (not pointer to a struct)",
+	}, {
+		label:     "OnlyCompareFields",
+		fnc:       OnlyCompareFields,
+		args:      args(&Foo1{}, "Alpha"),
+		wantPanic: "must be a non-pointer struct",
+		reason:    "the type must be a struct (not pointer to a struct)",
+	}, {
+		label:     "OnlyCompareFields",
+		fnc:       OnlyCompareFields,
+		args:      args(Foo1{}, "Delta"),
+		wantPanic: "Delta: does not exist in cmpopts.Foo1",
+		reason:    "the field must exist in the struct",
+	}, {
+		label:     "OnlyCompareFields",
+		fnc:       OnlyCompareFields,
+		args:      args(Foo2{}, "Alpha"),
+		wantPanic: "Alpha: does not exist in cmpopts.Foo2",
+		reason:    "only immediate fields may be specified",
+	}, {
+		label:     "IgnoreByValue",
+		fnc:       IgnoreByValue,
+		args:      args([]int{-1}),
+		wantPanic: "is not a comparable Go type",
+		reason:    "sentinel values must be comparable",
+	}, {
+		label:     "IgnoreByValue",
+		fnc:       IgnoreByValue,
+		args:      args(struct{ V interface{} }{[]int{-1}}),
+		wantPanic: "holds a value that is not comparable",
+		reason:    "sentinel values must not hold non-comparable values in interfaces",
+	}, {
+		label:     "IgnoreByValue",
+		fnc:       IgnoreByValue,
+		args:      args(math.NaN()),
+		wantPanic: "NaN sentinel can never match",
+		reason:    "NaN is never equal to itself",
+	}, {
+		label:     "IgnoreByValue",
+		fnc:       IgnoreByValue,
+		args:      args(struct{ F float64 }{math.NaN()}),
+		wantPanic: "NaN sentinel can never match",
+		reason:    "NaN is never equal to itself, even within a struct",
+	}, {
+		label:     "IgnoreUnexported",
+		fnc:       IgnoreUnexported,
+		args:      args(reflect.TypeOf(&Foo1{})),
+		wantPanic: "must be a non-pointer struct",
+		reason:    "input must be the reflect.Type of a struct (not a pointer to a struct)",
 	}, {
 		label:     "AcyclicTransformer",
 		fnc:       AcyclicTransformer,
 		args:      args("", "not a func"),
 		wantPanic: "invalid transformer function",
 		reason:    "AcyclicTransformer has same input requirements as Transformer",
+	}, {
+		label:     "TruncateSlices",
+		fnc:       TruncateSlices,
+		args:      args(-1),
+		wantPanic: "invalid number of elements",
+		reason:    "number of elements must be non-negative",
+	}, {
+		label:  "TruncateSlices",
+		fnc:    TruncateSlices,
+		args:   args(0),
+		reason: "zero elements is valid",
+	}, {
+		label:     "NormalizeStrings",
+		fnc:       NormalizeStrings,
+		args:      args(nil),
+		wantPanic: "invalid normalization function",
+		reason:    "normalization function must not be nil",
 	}}
 
 	for _, tt := range tests {
@@ -1419,3 +2468,14 @@ func TestPanic(t *testing.T) {
 		})
 	}
 }
+
+func TestSortSlicesNonTransitive(t *testing.T) {
+	defer func() {
+		ex := recover()
+		if s, _ := ex.(string); !strings.Contains(s, "non-transitive less or compare function detected") {
+			t.Errorf("panic message = %v, want non-transitive function message", ex)
+		}
+	}()
+	less := func(x, y int) bool { return (y-x+3)%3 == 1 } // 0 < 1 < 2 < 0
+	cmp.Equal([]int{0, 1, 2}, []int{2, 1, 0}, SortSlices(less))
+}
@@ -5,6 +5,9 @@
 package cmpopts
 
 import (
+	"fmt"
+	"reflect"
+
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -30,7 +33,68 @@ func (xf xformFilter) filter(p cmp.Path) bool {
 //
 // Had this been an unfiltered [cmp.Transformer] instead, this would result in an
 // infinite cycle converting a string to []string to [][]string and so on.
+//
+// The transformer is not applied if it already appears anywhere in the
+// current path, not just as the most recent transformation. Thus, this also
+// prevents cycles between multiple transformers, such as a pair of acyclic
+// transformers that convert a string to []byte and back again.
 func AcyclicTransformer(name string, xformFunc interface{}) cmp.Option {
 	xf := xformFilter{cmp.Transformer(name, xformFunc)}
 	return cmp.FilterPath(xf.filter, xf.xform)
 }
+
+// TruncateSlices returns a [cmp.Transformer] option that truncates all slices
+// longer than n elements to their first n elements before comparing them.
+// It is intended for keeping the reported differences of large slices
+// short while debugging. The number of elements must be non-negative.
+//
+// WARNING: All elements after the first n are silently ignored,
+// such that two slices that differ only in their tails are reported as equal.
+// Thus, this option is inappropriate for any check of equality
+// other than one where only a prefix of each slice is significant.
+func TruncateSlices(n int) cmp.Option {
+	if n < 0 {
+		panic(fmt.Sprintf("invalid number of elements: %d", n))
+	}
+	st := sliceTruncator{n}
+	return cmp.FilterValues(st.filter, cmp.Transformer("cmpopts.TruncateSlices", st.truncate))
+}
+
+type sliceTruncator struct{ n int }
+
+func (st sliceTruncator) filter(x, y interface{}) bool {
+	vx, vy := reflect.ValueOf(x), reflect.ValueOf(y)
+	return x != nil && y != nil && vx.Type() == vy.Type() &&
+		vx.Kind() == reflect.Slice && (vx.Len() > st.n || vy.Len() > st.n)
+}
+func (st sliceTruncator) truncate(x interface{}) interface{} {
+	v := reflect.ValueOf(x)
+	if v.Len() > st.n {
+		v = v.Slice3(0, st.n, st.n)
+	}
+	return v.Interface()
+}
+
+// NormalizeStrings returns a [cmp.Transformer] option that applies the
+// normalization function f to all strings, including named string types,
+// before comparing them. For example, f may normalize the Unicode form,
+// trim surrounding whitespace, or fold the case of each string.
+// The normalization is applied at most once to each string.
+func NormalizeStrings(f func(string) string) cmp.Option {
+	if f == nil {
+		panic("invalid normalization function: <nil>")
+	}
+	sn := stringNormalizer(f)
+	return cmp.FilterValues(areStrings, AcyclicTransformer("cmpopts.NormalizeStrings", sn.normalize))
+}
+
+type stringNormalizer func(string) string
+
+func areStrings(x, y interface{}) bool {
+	vx, vy := reflect.ValueOf(x), reflect.ValueOf(y)
+	return x != nil && y != nil && vx.Type() == vy.Type() && vx.Kind() == reflect.String
+}
+func (sn stringNormalizer) normalize(x interface{}) interface{} {
+	v := reflect.ValueOf(x)
+	return reflect.ValueOf(sn(v.String())).Convert(v.Type()).Interface()
+}
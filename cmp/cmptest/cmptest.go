@@ -0,0 +1,26 @@
+// Copyright 2026, The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cmptest provides helpers for using [cmp] in tests.
+//
+// It is a separate package so that the [cmp] package
+// does not depend on the [testing] package.
+package cmptest
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// AssertEqual reports a test error if want and got are not equal
+// according to [cmp.Equal]. The error contains the report produced by
+// [cmp.Diff], where removed lines are from want and inserted lines are
+// from got, preceded by a "mismatch (-want +got):" header.
+func AssertEqual(tb testing.TB, want, got interface{}, opts ...cmp.Option) {
+	tb.Helper()
+	if diff := cmp.Diff(want, got, opts...); diff != "" {
+		tb.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
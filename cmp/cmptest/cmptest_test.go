@@ -0,0 +1,67 @@
+// Copyright 2026, The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmptest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// fakeTB records the errors reported through it.
+type fakeTB struct {
+	testing.TB
+	errs   []string
+	helper bool
+}
+
+func (tb *fakeTB) Helper() { tb.helper = true }
+func (tb *fakeTB) Errorf(format string, args ...interface{}) {
+	tb.errs = append(tb.errs, fmt.Sprintf(format, args...))
+}
+
+func TestAssertEqual(t *testing.T) {
+	type S struct{ A, B int }
+	tests := []struct {
+		label     string
+		want, got interface{}
+		opts      []cmp.Option
+		wantError bool
+	}{{
+		label: "Equal",
+		want:  S{1, 2},
+		got:   S{1, 2},
+	}, {
+		label:     "Unequal",
+		want:      S{1, 2},
+		got:       S{1, 3},
+		wantError: true,
+	}, {
+		label: "Options",
+		want:  S{1, 2},
+		got:   S{1, 3},
+		opts:  []cmp.Option{cmpopts.IgnoreFields(S{}, "B")},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			tb := new(fakeTB)
+			AssertEqual(tb, tt.want, tt.got, tt.opts...)
+			if !tb.helper {
+				t.Errorf("AssertEqual did not call Helper")
+			}
+			if gotError := len(tb.errs) > 0; gotError != tt.wantError {
+				t.Fatalf("AssertEqual reported errors %q, want error: %v", tb.errs, tt.wantError)
+			}
+			if tt.wantError {
+				want := "mismatch (-want +got):\n" + cmp.Diff(tt.want, tt.got, tt.opts...)
+				if tb.errs[0] != want {
+					t.Errorf("AssertEqual error:\ngot  %q\nwant %q", tb.errs[0], want)
+				}
+			}
+		})
+	}
+}
@@ -32,6 +32,7 @@
 package cmp
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
@@ -58,8 +59,9 @@ import (
 //
 //   - If the values have an Equal method of the form "(T) Equal(T) bool" or
 //     "(T) Equal(I) bool" where T is assignable to I, then use the result of
-//     x.Equal(y) even if x or y is nil. Otherwise, no such method exists and
-//     evaluation proceeds to the next rule.
+//     x.Equal(y) even if x or y is nil. The method may also return an error
+//     as a second result, where a non-nil error reports the values as unequal.
+//     Otherwise, no such method exists and evaluation proceeds to the next rule.
 //
 //   - Lastly, try to compare x and y based on their basic kinds.
 //     Simple kinds like booleans, integers, floats, complex numbers, strings,
@@ -113,8 +115,66 @@ func Equal(x, y interface{}, opts ...Option) bool {
 // Do not depend on this output being stable. If you need the ability to
 // programmatically interpret the difference, consider using a custom Reporter.
 func Diff(x, y interface{}, opts ...Option) string {
+	return newState(opts).diff(x, y)
+}
+
+// SprintDiff formats the differences between x and y as a string.
+// It is an alias for [Diff] for those familiar with the naming of
+// [fmt.Sprint] and is identical in every respect.
+func SprintDiff(x, y interface{}, opts ...Option) string {
+	return Diff(x, y, opts...)
+}
+
+// EqualReason is like [Equal], but also returns a report of the first
+// difference between x and y, formatted in the same way as [Diff].
+// It returns an empty report if and only if the values are equal.
+// The comparison stops as soon as the first difference is found,
+// so the remainder of the values is neither compared nor formatted.
+func EqualReason(x, y interface{}, opts ...Option) (eq bool, reason string) {
 	s := newState(opts)
+	r := &firstDiffReporter{defaultReporter{opts: s.reportOpts}}
+	s.reporters = append(s.reporters, reporter{r})
+	defer func() {
+		if ex := recover(); ex != nil {
+			if _, ok := ex.(firstDiffFound); !ok {
+				panic(ex)
+			}
+		}
+		reason = r.String()
+		eq = reason == ""
+	}()
+	s.compareAny(rootStep(x, y))
+	return eq, reason
+}
+
+// EqualContext is like [Equal], but periodically checks whether ctx is done
+// while comparing the values. If so, it stops comparing the values and
+// returns false along with the error reported by ctx.Err.
+func EqualContext(ctx context.Context, x, y interface{}, opts ...Option) (eq bool, err error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	defer recoverContext(&err)
+	s := newState(opts)
+	s.ctxChecker.ctx = ctx
+	s.compareAny(rootStep(x, y))
+	return s.result.Equal(), nil
+}
 
+// DiffContext is like [Diff], but periodically checks whether ctx is done
+// while comparing the values. If so, it stops comparing the values and
+// returns an empty string along with the error reported by ctx.Err.
+func DiffContext(ctx context.Context, x, y interface{}, opts ...Option) (d string, err error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	defer recoverContext(&err)
+	s := newState(opts)
+	s.ctxChecker.ctx = ctx
+	return s.diff(x, y), nil
+}
+
+func (s *state) diff(x, y interface{}) string {
 	// Optimization: If there are no other reporters, we can optimize for the
 	// common case where the result is equal (and thus no reported difference).
 	// This avoids the expensive construction of a difference tree.
@@ -126,7 +186,7 @@ func Diff(x, y interface{}, opts ...Option) string {
 		s.result = diff.Result{} // Reset results
 	}
 
-	r := new(defaultReporter)
+	r := &defaultReporter{opts: s.reportOpts}
 	s.reporters = append(s.reporters, reporter{r})
 	s.compareAny(rootStep(x, y))
 	d := r.String()
@@ -136,6 +196,52 @@ func Diff(x, y interface{}, opts ...Option) string {
 	return d
 }
 
+// DiffResult is a structured report of the differences between two values
+// as returned by [DiffStructured].
+type DiffResult struct {
+	// Equal reports whether the values are equal.
+	// It is identical to the result of [Equal] for the same inputs.
+	Equal bool
+
+	// Diffs is the list of leaf nodes that were determined to be unequal,
+	// in the order in which they were visited.
+	Diffs []DiffEntry
+}
+
+// DiffEntry is a single difference reported by [DiffStructured].
+type DiffEntry struct {
+	// Path is the path to the unequal values.
+	// It remains valid after DiffStructured returns.
+	Path Path
+
+	// X and Y are the unequal values, which are obtained from
+	// [PathStep.Values] on the last step in the Path.
+	// A value is invalid if it does not exist (e.g., a missing map entry).
+	X, Y reflect.Value
+}
+
+// DiffStructured compares x and y and returns the differences as a
+// [DiffResult] so that they can be programmatically inspected.
+// It reports exactly the leaf nodes that would cause [Equal] to report false.
+// Use [Diff] for a human-readable report.
+func DiffStructured(x, y interface{}, opts ...Option) DiffResult {
+	s := newState(opts)
+	r := new(structuredReporter)
+	s.reporters = append(s.reporters, reporter{r})
+	s.compareAny(rootStep(x, y))
+	return DiffResult{Equal: s.result.Equal(), Diffs: r.diffs}
+}
+
+// CountDiffs reports the number of leaf nodes that differ between x and y.
+// It returns zero if and only if [Equal] reports true for the same inputs.
+// The count is identical to the number of entries reported by [DiffStructured],
+// but it is obtained without recording paths or formatting a report.
+func CountDiffs(x, y interface{}, opts ...Option) int {
+	s := newState(opts)
+	s.compareAny(rootStep(x, y))
+	return s.result.NumDiff
+}
+
 // rootStep constructs the first path step. If x and y have differing types,
 // then they are stored within an empty interface type.
 func rootStep(x, y interface{}) PathStep {
@@ -180,14 +286,21 @@ type state struct {
 	// It is safe for statelessCompare to mutate this value.
 	dynChecker dynChecker
 
+	// ctxChecker periodically checks whether the comparison was canceled.
+	// It is safe for statelessCompare to mutate this value.
+	ctxChecker ctxChecker
+
 	// These fields, once set by processOption, will not change.
-	exporters []exporter // List of exporters for structs with unexported fields
-	opts      Options    // List of all fundamental and filter options
+	exporters  []exporter    // List of exporters for structs with unexported fields
+	opts       Options       // List of all fundamental and filter options
+	reportOpts reportOptions // Options for the report produced by Diff
 }
 
 func newState(opts []Option) *state {
 	// Always ensure a validator option exists to validate the inputs.
 	s := &state{opts: Options{validator{}}}
+	s.reportOpts.NumContextRecords = numContextRecords
+	s.reportOpts.Verbosity = defaultVerbosity
 	s.curPtrs.Init()
 	s.processOption(Options(opts))
 	return s
@@ -212,6 +325,8 @@ func (s *state) processOption(opt Option) {
 		s.exporters = append(s.exporters, opt)
 	case reporter:
 		s.reporters = append(s.reporters, opt)
+	case reportOption:
+		opt(&s.reportOpts)
 	default:
 		panic(fmt.Sprintf("unknown option %T", opt))
 	}
@@ -244,6 +359,7 @@ func (s *state) compareAny(step PathStep) {
 		defer r.PopStep()
 	}
 	s.recChecker.Check(s.curPath)
+	s.ctxChecker.Check()
 
 	// Cycle-detection for slice elements (see NOTE in compareSlice).
 	t := step.Type()
@@ -312,7 +428,8 @@ func (s *state) tryOptions(t reflect.Type, vx, vy reflect.Value) bool {
 func (s *state) tryMethod(t reflect.Type, vx, vy reflect.Value) bool {
 	// Check if this type even has an Equal method.
 	m, ok := t.MethodByName("Equal")
-	if !ok || !function.IsType(m.Type, function.EqualAssignable) {
+	if !ok || !(function.IsType(m.Type, function.EqualAssignable) ||
+		function.IsType(m.Type, function.EqualAssignableErr)) {
 		return false
 	}
 
@@ -323,16 +440,16 @@ func (s *state) tryMethod(t reflect.Type, vx, vy reflect.Value) bool {
 
 func (s *state) callTRFunc(f, v reflect.Value, step Transform) reflect.Value {
 	if !s.dynChecker.Next() {
-		return f.Call([]reflect.Value{v})[0]
+		return callTransform(f, v, step)
 	}
 
 	// Run the function twice and ensure that we get the same results back.
 	// We run in goroutines so that the race detector (if enabled) can detect
 	// unsafe mutations to the input.
 	c := make(chan reflect.Value)
-	go detectRaces(c, f, v)
+	go detectRaces(c, func() reflect.Value { return f.Call([]reflect.Value{v})[0] })
 	got := <-c
-	want := f.Call([]reflect.Value{v})[0]
+	want := callTransform(f, v, step)
 	if step.vx, step.vy = got, want; !s.statelessCompare(step).Equal() {
 		// To avoid false-positives with non-reflexive equality operations,
 		// we sanity check whether a value is equal to itself.
@@ -344,9 +461,27 @@ func (s *state) callTRFunc(f, v reflect.Value, step Transform) reflect.Value {
 	return want
 }
 
+// callTransform calls the transformer function f on v.
+// It panics if f returns a non-nil error.
+func callTransform(f, v reflect.Value, step Transform) reflect.Value {
+	out := f.Call([]reflect.Value{v})
+	if len(out) == 2 && !out[1].IsNil() {
+		panic(fmt.Sprintf("transformer %s returned an error: %v", step.Name(), out[1].Interface()))
+	}
+	return out[0]
+}
+
 func (s *state) callTTBFunc(f, x, y reflect.Value) bool {
+	eq, _ := s.callTTBSFunc(f, x, y, callEqual)
+	return eq
+}
+
+// callTTBSFunc is like callTTBFunc, but also returns the reason that
+// x and y are unequal if f is a func(T, T) (bool, string).
+// The function f is invoked through call, which is usually callEqual.
+func (s *state) callTTBSFunc(f, x, y reflect.Value, call func(f, x, y reflect.Value) (bool, string)) (bool, string) {
 	if !s.dynChecker.Next() {
-		return f.Call([]reflect.Value{x, y})[0].Bool()
+		return call(f, x, y)
 	}
 
 	// Swapping the input arguments is sufficient to check that
@@ -354,22 +489,43 @@ func (s *state) callTTBFunc(f, x, y reflect.Value) bool {
 	// We run in goroutines so that the race detector (if enabled) can detect
 	// unsafe mutations to the input.
 	c := make(chan reflect.Value)
-	go detectRaces(c, f, y, x)
+	go detectRaces(c, func() reflect.Value {
+		eq, _ := call(f, y, x)
+		return reflect.ValueOf(eq)
+	})
 	got := <-c
-	want := f.Call([]reflect.Value{x, y})[0].Bool()
+	want, reason := call(f, x, y)
 	if !got.IsValid() || got.Bool() != want {
 		panic(fmt.Sprintf("non-deterministic or non-symmetric function detected: %s", function.NameOf(f)))
 	}
-	return want
+	return want, reason
+}
+
+// callEqual calls the equality function f on x and y.
+// If f also returns an error, then a non-nil error reports inequality.
+// If f also returns a string, then it is the reason for any inequality.
+func callEqual(f, x, y reflect.Value) (eq bool, reason string) {
+	out := f.Call([]reflect.Value{x, y})
+	eq = out[0].Bool()
+	if len(out) == 2 {
+		if out[1].Kind() == reflect.String {
+			if !eq {
+				reason = out[1].String()
+			}
+		} else {
+			eq = eq && out[1].IsNil()
+		}
+	}
+	return eq, reason
 }
 
-func detectRaces(c chan<- reflect.Value, f reflect.Value, vs ...reflect.Value) {
+func detectRaces(c chan<- reflect.Value, call func() reflect.Value) {
 	var ret reflect.Value
 	defer func() {
 		recover() // Ignore panics, let the other call to f panic instead
 		c <- ret
 	}()
-	ret = f.Call(vs)[0]
+	ret = call()
 }
 
 func (s *state) compareStruct(t reflect.Type, vx, vy reflect.Value) {
@@ -377,7 +533,7 @@ func (s *state) compareStruct(t reflect.Type, vx, vy reflect.Value) {
 	var vax, vay reflect.Value // Addressable versions of vx and vy
 
 	var mayForce, mayForceInit bool
-	step := StructField{&structField{}}
+	step := StructField{&structField{ptyp: t}}
 	for i := 0; i < t.NumField(); i++ {
 		step.typ = t.Field(i).Type
 		step.vx = vx.Field(i)
@@ -580,6 +736,12 @@ func (s *state) compareInterface(t reflect.Type, vx, vy reflect.Value) {
 }
 
 func (s *state) report(eq bool, rf resultFlags) {
+	s.reportReason(eq, rf, "")
+}
+
+// reportReason is like report, but also reports the reason that
+// the values are unequal as provided by a comparer.
+func (s *state) reportReason(eq bool, rf resultFlags, reason string) {
 	if rf&reportByIgnore == 0 {
 		if eq {
 			s.result.NumSame++
@@ -590,7 +752,46 @@ func (s *state) report(eq bool, rf resultFlags) {
 		}
 	}
 	for _, r := range s.reporters {
-		r.Report(Result{flags: rf})
+		r.Report(Result{flags: rf, reason: reason})
+	}
+}
+
+// ctxChecker tracks the state needed to periodically check whether the
+// context provided to EqualContext or DiffContext is done.
+type ctxChecker struct {
+	ctx  context.Context // May be nil
+	next int
+}
+
+// Check panics with a ctxError if the context is done.
+// To reduce overhead, the context is only checked every so often.
+func (cc *ctxChecker) Check() {
+	const interval = 1 << 8
+	if cc.ctx == nil {
+		return
+	}
+	if cc.next++; cc.next < interval {
+		return
+	}
+	cc.next = 0
+	if err := cc.ctx.Err(); err != nil {
+		panic(ctxError{err})
+	}
+}
+
+// ctxError is the panic value used to unwind the comparison once the
+// context is done. It is recovered by recoverContext.
+type ctxError struct{ err error }
+
+// recoverContext recovers a ctxError panic and stores its error in err.
+// Any other panic is propagated.
+func recoverContext(err *error) {
+	if ex := recover(); ex != nil {
+		ce, ok := ex.(ctxError)
+		if !ok {
+			panic(ex)
+		}
+		*err = ce.err
 	}
 }
 
@@ -6,6 +6,7 @@ package cmp_test
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha256"
 	"encoding/json"
 	"errors"
@@ -17,6 +18,7 @@ import (
 	"math/rand"
 	"reflect"
 	"regexp"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
@@ -124,6 +126,7 @@ type test struct {
 func TestDiff(t *testing.T) {
 	var tests []test
 	tests = append(tests, comparerTests()...)
+	tests = append(tests, filterPathTests()...)
 	tests = append(tests, transformerTests()...)
 	tests = append(tests, reporterTests()...)
 	tests = append(tests, embeddedTests()...)
@@ -281,6 +284,26 @@ func comparerTests() []test {
 		},
 		wantPanic: "ambiguous set of applicable options",
 		reason:    "both options apply on int, leading to ambiguity",
+	}, {
+		label: label + "/MoreSpecificComparer",
+		x:     Stringer("hello"),
+		y:     Stringer("goodbye"),
+		opts: []cmp.Option{
+			cmp.Comparer(func(x, y fmt.Stringer) bool { return false }),
+			cmp.Comparer(func(x, y Stringer) bool { return true }),
+		},
+		wantEqual: true,
+		reason:    "comparer on Stringer is preferred since it is more specific than fmt.Stringer",
+	}, {
+		label: label + "/MoreSpecificComparerReversed",
+		x:     Stringer("hello"),
+		y:     Stringer("goodbye"),
+		opts: []cmp.Option{
+			cmp.Comparer(func(x, y Stringer) bool { return true }),
+			cmp.Comparer(func(x, y fmt.Stringer) bool { return false }),
+		},
+		wantEqual: true,
+		reason:    "comparer on Stringer is preferred regardless of the order of options",
 	}, {
 		label: label + "/IgnorePrecedence",
 		x:     1,
@@ -710,6 +733,150 @@ func comparerTests() []test {
 		opts:      []cmp.Option{cmpopts.EquateErrors()},
 		wantEqual: true,
 		reason:    "cmpopts.EquateErrors should equate these two errors as sentinel values",
+	}, {
+		label: label + "/FilterFieldIgnore",
+		x:     tarHeader{Name: "file", ModTime: now},
+		y:     tarHeader{Name: "file", ModTime: now.Add(time.Hour)},
+		opts: []cmp.Option{
+			cmp.FilterField(tarHeader{}, "ModTime", cmp.Ignore()),
+		},
+		wantEqual: true,
+		reason:    "equal because ModTime is ignored",
+	}, {
+		label: label + "/FilterFieldNested",
+		x:     tarHeader{Name: "file", Uname: "user", Xattrs: map[string]string{"a": "x"}},
+		y:     tarHeader{Name: "file", Uname: "USER", Xattrs: map[string]string{"a": "X"}},
+		opts: []cmp.Option{
+			cmp.FilterField(tarHeader{}, "Xattrs", cmp.Comparer(strings.EqualFold)),
+		},
+		wantEqual: false,
+		reason:    "not equal because the comparer only applies to strings nested within Xattrs and not Uname",
+	}, {
+		label: label + "/FilterValuesWithPath",
+		x:     []tarHeader{{Name: "a", Size: 100, Mode: 0644}, {Name: "b", Size: 200}},
+		y:     []tarHeader{{Name: "a", Size: 105, Mode: 0600}, {Name: "b", Size: 201}},
+		opts: []cmp.Option{
+			cmp.FilterValuesWithPath(func(p cmp.Path, x, y interface{}) bool {
+				return p.Last().String() == ".Size" && math.Abs(float64(x.(int64)-y.(int64))) <= 10
+			}, cmp.Ignore()),
+		},
+		wantEqual: false,
+		reason:    "not equal because only Size is ignored when it is within a margin, but Mode differs",
+	}, {
+		label: label + "/NotFilterField",
+		x:     tarHeader{Name: "file", Uname: "user", Xattrs: map[string]string{"a": "x"}},
+		y:     tarHeader{Name: "file", Uname: "USER", Xattrs: map[string]string{"a": "x"}},
+		opts: []cmp.Option{
+			cmp.Not(cmp.FilterField(tarHeader{}, "Xattrs", cmp.Comparer(strings.EqualFold))),
+		},
+		wantEqual: true,
+		reason:    "equal because the comparer applies to strings everywhere except within Xattrs",
+	}, {
+		label: label + "/NotFilterFieldInverse",
+		x:     tarHeader{Name: "file", Uname: "user", Xattrs: map[string]string{"a": "x"}},
+		y:     tarHeader{Name: "file", Uname: "user", Xattrs: map[string]string{"a": "X"}},
+		opts: []cmp.Option{
+			cmp.Not(cmp.FilterField(tarHeader{}, "Xattrs", cmp.Comparer(strings.EqualFold))),
+		},
+		wantEqual: false,
+		reason:    "not equal because the comparer does not apply to strings within Xattrs",
+	}, {
+		label: label + "/NotNot",
+		x:     tarHeader{Name: "file", Uname: "user", Xattrs: map[string]string{"a": "x"}},
+		y:     tarHeader{Name: "file", Uname: "user", Xattrs: map[string]string{"a": "X"}},
+		opts: []cmp.Option{
+			cmp.Not(cmp.Not(cmp.FilterField(tarHeader{}, "Xattrs", cmp.Comparer(strings.EqualFold)))),
+		},
+		wantEqual: true,
+		reason:    "equal because inverting a filter twice restores the original filter",
+	}, {
+		label: label + "/ComparerWithReason",
+		x:     tarHeader{Name: "file", Size: 100, Mode: 0644},
+		y:     tarHeader{Name: "file", Size: 101, Mode: 0600},
+		opts: []cmp.Option{
+			cmp.ComparerWithReason(func(x, y int64) (bool, string) {
+				if x == y {
+					return true, ""
+				}
+				return false, fmt.Sprintf("values differ by %d", y-x)
+			}),
+		},
+		wantEqual: false,
+		reason:    "should show the reason provided by the comparer",
+	}, {
+		label: label + "/ComparerWithReasonSideBySide",
+		x:     tarHeader{Name: "file", Size: 100, Mode: 0644},
+		y:     tarHeader{Name: "file", Size: 101, Mode: 0600},
+		opts: []cmp.Option{
+			cmp.ComparerWithReason(func(x, y int64) (bool, string) {
+				if x == y {
+					return true, ""
+				}
+				return false, fmt.Sprintf("values differ by %d", y-x)
+			}),
+			cmp.SideBySide(),
+		},
+		wantEqual: false,
+		reason:    "should show the reason provided by the comparer",
+	}, {
+		label: label + "/ComparerWithReasonEqual",
+		x:     tarHeader{Name: "file", Size: 100},
+		y:     tarHeader{Name: "file", Size: 100},
+		opts: []cmp.Option{
+			cmp.ComparerWithReason(func(x, y int64) (bool, string) { return x == y, "ignored" }),
+		},
+		wantEqual: true,
+		reason:    "equal because the comparer reports equality",
+	}}
+}
+
+func filterPathTests() []test {
+	const label = "FilterPath"
+
+	type (
+		Item      struct{ N, M int }
+		Inventory struct {
+			Items  []Item
+			ByName map[string]*Item
+		}
+	)
+
+	return []test{{
+		label: label + "/StringAnyDepth",
+		x:     []Inventory{{Items: []Item{{N: 1, M: 2}}}},
+		y:     []Inventory{{Items: []Item{{N: 3, M: 2}}}},
+		opts: []cmp.Option{
+			cmp.FilterPathString("**.N", cmp.Ignore()),
+		},
+		wantEqual: true,
+		reason:    "equal because the N field is ignored at any depth",
+	}, {
+		label: label + "/StringSliceElements",
+		x:     Inventory{Items: []Item{{N: 1, M: 2}, {N: 3, M: 4}}},
+		y:     Inventory{Items: []Item{{N: 5, M: 2}, {N: 6, M: 4}}},
+		opts: []cmp.Option{
+			cmp.FilterPathString("Items.*.N", cmp.Ignore()),
+		},
+		wantEqual: true,
+		reason:    "equal because * matches any slice element",
+	}, {
+		label: label + "/StringMapEntries",
+		x:     Inventory{ByName: map[string]*Item{"a": {N: 1, M: 2}, "b": {N: 3, M: 4}}},
+		y:     Inventory{ByName: map[string]*Item{"a": {N: 5, M: 2}, "b": {N: 6, M: 4}}},
+		opts: []cmp.Option{
+			cmp.FilterPathString("ByName.*.N", cmp.Ignore()),
+		},
+		wantEqual: true,
+		reason:    "equal because * matches any map entry",
+	}, {
+		label: label + "/StringMismatch",
+		x:     Inventory{Items: []Item{{N: 1, M: 2}, {N: 3, M: 4}}},
+		y:     Inventory{Items: []Item{{N: 5, M: 2}, {N: 6, M: 4}}},
+		opts: []cmp.Option{
+			cmp.FilterPathString("Items[0].N", cmp.Ignore()),
+		},
+		wantEqual: false,
+		reason:    "not equal because the N field is only ignored in the first element",
 	}}
 }
 
@@ -854,6 +1021,20 @@ func transformerTests() []test {
 		},
 		wantPanic: "recursive set of Transformers detected",
 		reason:    "cyclic transformation from complex64 -> complex128 -> [2]float64 -> complex64",
+	}, {
+		label:     label + "/WithError",
+		x:         []string{"1", "+2"},
+		y:         []string{"01", "2"},
+		opts:      []cmp.Option{cmp.Transformer("Atoi", strconv.Atoi)},
+		wantEqual: true,
+		reason:    "transformer may return an error, which is nil for valid integers",
+	}, {
+		label:     label + "/WithNonNilError",
+		x:         []string{"1", "two"},
+		y:         []string{"1", "2"},
+		opts:      []cmp.Option{cmp.Transformer("Atoi", strconv.Atoi)},
+		wantPanic: "transformer Atoi returned an error",
+		reason:    "transformer panics when returning a non-nil error",
 	}}
 }
 
@@ -887,6 +1068,11 @@ func reporterTests() []test {
 			FloatsC MyFloats
 		}
 		PointerString *string
+		MyInner       struct{ ID int }
+		MyOuter       struct {
+			ID    int
+			Inner MyInner
+		}
 	)
 
 	return []test{{
@@ -1420,6 +1606,190 @@ using the AllowUnexported option.`, "\n"),
 	{"Base32": "NBSWY3DP"}
 ]`,
 		reason: "should use line-based diffing since byte-based diffing is unreadable due to heavy amounts of escaping",
+	}, {
+		label: label + "/WithSummary",
+		x: map[string]MyComposite{
+			"same":    {StringA: "a"},
+			"removed": {StringA: "b"},
+			"changed": {StringA: "c", IntsA: []int8{1, 2, 3}, FloatsA: []float32{1.5}},
+		},
+		y: map[string]MyComposite{
+			"same":    {StringA: "a"},
+			"added":   {StringA: "d"},
+			"changed": {StringA: "C", IntsA: []int8{1, 3, 4, 5}, FloatsA: []float32{2.5}},
+		},
+		opts:      []cmp.Option{cmp.WithSummary()},
+		wantEqual: false,
+		reason:    "should append a summary with counts of leaf insertions, deletions, and modifications",
+	}, {
+		label:     label + "/WithSummarySingular",
+		x:         []int{1, 2},
+		y:         []int{1, 3, 4},
+		opts:      []cmp.Option{cmp.WithSummary()},
+		wantEqual: false,
+		reason:    "should use the singular form for a count of one",
+	}, {
+		label: label + "/WithMaxDiffs",
+		x: map[string]MyComposite{
+			"a": {StringA: "a", StringB: "b", IntsA: []int8{1}},
+			"b": {StringA: "a"},
+			"c": {StringA: "a"},
+		},
+		y: map[string]MyComposite{
+			"a": {StringA: "A", StringB: "B", IntsA: []int8{2}},
+			"b": {StringA: "a"},
+			"c": {StringA: "A"},
+		},
+		opts:      []cmp.Option{cmp.WithMaxDiffs(2), cmp.WithSummary()},
+		wantEqual: false,
+		reason:    "should only report the first two differences, while the summary counts all of them",
+	}, {
+		label:     label + "/WithMaxDiffsUnderLimit",
+		x:         []int{1, 2},
+		y:         []int{1, 3},
+		opts:      []cmp.Option{cmp.WithMaxDiffs(2)},
+		wantEqual: false,
+		reason:    "should report every difference without a trailer",
+	}, {
+		label: label + "/WithMaxDiffsMultilineString",
+		x: []string{
+			"line 1\nline 2\nline 3\nline 4\nline 5\n",
+			"a",
+		},
+		y: []string{
+			"line 1\nLINE 2\nline 3\nLINE 4\nline 5\n",
+			"b",
+		},
+		opts:      []cmp.Option{cmp.WithMaxDiffs(1)},
+		wantEqual: false,
+		reason:    "should count a multi-line string as a single difference, printing every differing line",
+	}, {
+		label:     label + "/WithContextNone",
+		x:         []int{1, 2, 3, 4, 5, 6, 7, 8},
+		y:         []int{1, 2, 3, 0, 5, 6, 7, 8},
+		opts:      []cmp.Option{cmp.WithContext(0)},
+		wantEqual: false,
+		reason:    "should elide every equal element",
+	}, {
+		label:     label + "/WithContextAll",
+		x:         []int{1, 2, 3, 4, 5, 6, 7, 8},
+		y:         []int{1, 2, 3, 0, 5, 6, 7, 8},
+		opts:      []cmp.Option{cmp.WithContext(-1)},
+		wantEqual: false,
+		reason:    "should print every equal element",
+	}, {
+		label: label + "/WithContextRecords",
+		x: map[string]int{
+			"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6, "g": 7,
+		},
+		y: map[string]int{
+			"a": 1, "b": 2, "c": 3, "d": 0, "e": 5, "f": 6, "g": 7,
+		},
+		opts:      []cmp.Option{cmp.WithContext(1)},
+		wantEqual: false,
+		reason:    "should print one equal entry around the difference",
+	}, {
+		label: label + "/WithStringDiffAlgorithmDefault",
+		x: strings.Join([]string{
+			"func A() {", "}",
+			"func B() {", "}",
+			"func C() {", "}",
+			"func D() {", "}",
+		}, "\n"),
+		y: strings.Join([]string{
+			"func A() {", "}",
+			"func D() {", "}",
+			"func B() {", "}",
+			"func C() {", "}",
+		}, "\n"),
+		wantEqual: false,
+		reason:    "should difference lines with the default algorithm",
+	}, {
+		label: label + "/WithStringDiffAlgorithmPatience",
+		x: strings.Join([]string{
+			"func A() {", "}",
+			"func B() {", "}",
+			"func C() {", "}",
+			"func D() {", "}",
+		}, "\n"),
+		y: strings.Join([]string{
+			"func A() {", "}",
+			"func D() {", "}",
+			"func B() {", "}",
+			"func C() {", "}",
+		}, "\n"),
+		opts:      []cmp.Option{cmp.WithStringDiffAlgorithm(cmp.PatienceStringDiff)},
+		wantEqual: false,
+		reason:    "should align the differences on the unique function declarations",
+	}, {
+		label: label + "/SideBySide",
+		x: map[string]MyComposite{
+			"a": {StringA: "a", StringB: "x", IntsA: []int8{1, 2}},
+		},
+		y: map[string]MyComposite{
+			"a": {StringA: "b", StringB: "x", IntsA: []int8{1, 3, 4}},
+			"b": {},
+		},
+		opts:      []cmp.Option{cmp.SideBySide()},
+		wantEqual: false,
+		reason:    "should format modified leaf values on a single line, but inserted values as usual",
+	}, {
+		label:     label + "/WithUnifiedDiff",
+		x:         "alpha\nbravo\ncharlie\ndelta\necho\nfoxtrot\ngolf\nhotel\nindia\njuliett\nkilo\n",
+		y:         "alpha\nBRAVO\ncharlie\ndelta\necho\nfoxtrot\ngolf\nhotel\nindia\njuliett\njuliett2\nkilo\n",
+		opts:      []cmp.Option{cmp.WithUnifiedDiff()},
+		wantEqual: false,
+		reason:    "should format the differences as a unified diff with separate hunks",
+	}, {
+		label:     label + "/WithUnifiedDiffContext",
+		x:         "alpha\nbravo\ncharlie\ndelta\necho\nfoxtrot\ngolf\nhotel\nindia\njuliett\nkilo\n",
+		y:         "alpha\nBRAVO\ncharlie\ndelta\necho\nfoxtrot\ngolf\nhotel\nindia\njuliett\njuliett2\nkilo\n",
+		opts:      []cmp.Option{cmp.WithUnifiedDiff(), cmp.WithContext(4)},
+		wantEqual: false,
+		reason:    "should merge hunks with overlapping context into a single hunk",
+	}, {
+		label: label + "/WithVerbosityMinimal",
+		x: []interface{}{
+			&MyComposite{StringA: "a", IntsA: []int8{1, 2, 3, 4, 5, 6, 7, 8}},
+		},
+		y: []interface{}{
+			map[string][]int{"a": {1, 2, 3, 4, 5, 6, 7, 8}},
+		},
+		opts:      []cmp.Option{cmp.WithVerbosity(0)},
+		wantEqual: false,
+		reason:    "should elide nested values sooner than usual",
+	}, {
+		label: label + "/WithVerbosityFull",
+		x: []interface{}{
+			&MyComposite{StringA: "a", IntsA: []int8{1, 2, 3, 4, 5, 6, 7, 8}},
+		},
+		y: []interface{}{
+			map[string][]int{"a": {1, 2, 3, 4, 5, 6, 7, 8}},
+		},
+		opts:      []cmp.Option{cmp.WithVerbosity(3)},
+		wantEqual: false,
+		reason:    "should print qualified type names and addresses",
+	}, {
+		label:     label + "/WithVerbosityStringer",
+		x:         []fmt.Stringer{Stringer("hello")},
+		y:         []fmt.Stringer{Stringer("goodbye")},
+		opts:      []cmp.Option{cmp.WithVerbosity(2)},
+		wantEqual: false,
+		reason:    "should avoid calling the String method",
+	}, {
+		label:     label + "/WithVerbosityQualifiedFields",
+		x:         MyOuter{ID: 1, Inner: MyInner{ID: 2}},
+		y:         MyOuter{ID: 3, Inner: MyInner{ID: 4}},
+		opts:      []cmp.Option{cmp.WithVerbosity(3)},
+		wantEqual: false,
+		reason:    "should qualify field names with their parent type",
+	}, {
+		label:     label + "/WithoutTypes",
+		x:         MyOuter{ID: 1, Inner: MyInner{ID: 2}},
+		y:         MyOuter{ID: 1, Inner: MyInner{ID: 3}},
+		opts:      []cmp.Option{cmp.WithoutTypes()},
+		wantEqual: false,
+		reason:    "should omit all type names",
 	}}
 }
 
@@ -2369,9 +2739,34 @@ func methodTests() []test {
 		y:         ts.AssignD(make(chan bool)),
 		wantEqual: true,
 		reason:    "Equal method called since named channel is assignable to unnamed channel",
+	}, {
+		label:     label + "/EqualError/Equal",
+		x:         equalError{V: 1},
+		y:         equalError{V: 11},
+		wantEqual: true,
+		reason:    "Equal method with an error result called",
+	}, {
+		label:     label + "/EqualError/Error",
+		x:         equalError{V: 1},
+		y:         equalError{V: 1, Invalid: true},
+		wantEqual: false,
+		reason:    "Equal method with a non-nil error reports inequality",
 	}}
 }
 
+// equalError has an Equal method that also returns an error.
+type equalError struct {
+	V       int
+	Invalid bool
+}
+
+func (x equalError) Equal(y equalError) (bool, error) {
+	if x.Invalid || y.Invalid {
+		return true, errors.New("invalid value")
+	}
+	return x.V%10 == y.V%10, nil
+}
+
 type (
 	CycleAlpha struct {
 		Name   string
@@ -2957,6 +3352,224 @@ func project4Tests() []test {
 	}}
 }
 
+func TestDiffColor(t *testing.T) {
+	x := []int{1, 2, 3}
+	y := []int{1, 4, 3}
+	got := cmp.Diff(x, y, cmp.WithColor())
+	want := "  []int{\n" +
+		"  \t1,\n" +
+		"\x1b[31m- \t2,\x1b[0m\n" +
+		"\x1b[32m+ \t4,\x1b[0m\n" +
+		"  \t3,\n" +
+		"  }\n"
+	if got != want {
+		t.Errorf("Diff mismatch:\ngot:  %q\nwant: %q", got, want)
+	}
+	if got := cmp.Diff(x, x, cmp.WithColor()); got != "" {
+		t.Errorf("Diff = %q, want empty", got)
+	}
+}
+
+func TestMostSpecificComparer(t *testing.T) {
+	type (
+		StringIface1 interface{ String() string }
+		StringIface2 interface{ String() string }
+	)
+	// The comparers on StringIface1 and StringIface2 cannot be ranked against
+	// each other, but the comparer on Stringer is more specific than both.
+	opts := []cmp.Option{
+		cmp.Comparer(func(x, y StringIface1) bool { return false }),
+		cmp.Comparer(func(x, y StringIface2) bool { return false }),
+		cmp.Comparer(func(x, y Stringer) bool { return true }),
+	}
+	perms := [][]int{{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0}}
+	for _, perm := range perms {
+		var permOpts []cmp.Option
+		for _, i := range perm {
+			permOpts = append(permOpts, opts[i])
+		}
+		func() {
+			defer func() {
+				if ex := recover(); ex != nil {
+					t.Errorf("Equal with options in order %v panicked: %v", perm, ex)
+				}
+			}()
+			if !cmp.Equal(Stringer("hello"), Stringer("goodbye"), permOpts...) {
+				t.Errorf("Equal with options in order %v = false, want true", perm)
+			}
+		}()
+	}
+
+	// Without a comparer that is more specific than all others,
+	// the set of options is ambiguous regardless of order.
+	for _, perm := range [][]int{{0, 1}, {1, 0}} {
+		func() {
+			defer func() {
+				if ex := recover(); ex == nil || !strings.Contains(fmt.Sprint(ex), "ambiguous set of applicable options") {
+					t.Errorf("Equal with options in order %v panic = %v, want ambiguous set", perm, ex)
+				}
+			}()
+			cmp.Equal(Stringer("hello"), Stringer("goodbye"), opts[perm[0]], opts[perm[1]])
+		}()
+	}
+}
+
+func TestComparerPanic(t *testing.T) {
+	type S struct{ A, B []int }
+	x, y := S{A: []int{1}, B: []int{2}}, S{A: []int{1}, B: []int{3}}
+
+	// recoverPanic calls f and returns the value that it panicked with.
+	recoverPanic := func(f func()) (ex interface{}) {
+		defer func() { ex = recover() }()
+		f()
+		return nil
+	}
+
+	// A panic in a Comparer is wrapped with the path and original value.
+	type customPanic struct{ msg string }
+	ex := recoverPanic(func() {
+		cmp.Equal(x, y, cmp.Comparer(func(x, y []int) bool {
+			if x[0] != y[0] {
+				panic(customPanic{"boom"})
+			}
+			return true
+		}))
+	})
+	cp, ok := ex.(*cmp.ComparerPanic)
+	if !ok {
+		t.Fatalf("panic value = %T, want *cmp.ComparerPanic", ex)
+	}
+	if got, want := cp.Path.GoString(), "{cmp_test.S}.B"; got != want {
+		t.Errorf("ComparerPanic.Path = %v, want %v", got, want)
+	}
+	if got, want := cp.Value, (customPanic{"boom"}); got != want {
+		t.Errorf("ComparerPanic.Value = %v, want %v", got, want)
+	}
+	if got, want := cp.Error(), "panic at {cmp_test.S}.B: {boom}"; got != want {
+		t.Errorf("ComparerPanic.Error() = %q, want %q", got, want)
+	}
+
+	// A runtime error can be unwrapped.
+	ex = recoverPanic(func() {
+		cmp.Equal(x, y, cmp.Comparer(func(x, y []int) bool { return x[1] == y[1] }))
+	})
+	var re runtime.Error
+	if err, ok := ex.(error); !ok || !errors.As(err, &re) {
+		t.Errorf("panic value = %v, want a wrapped runtime.Error", ex)
+	}
+
+	// A panic from a nested call to Equal is not wrapped again.
+	inner := cmp.Comparer(func(x, y int) bool { panic("boom") })
+	ex = recoverPanic(func() {
+		cmp.Equal(x, y, cmp.Comparer(func(x, y []int) bool { return cmp.Equal(x, y, inner) }))
+	})
+	if cp, ok := ex.(*cmp.ComparerPanic); !ok || cp.Value != "boom" {
+		t.Errorf("panic value = %#v, want *cmp.ComparerPanic wrapping \"boom\" once", ex)
+	}
+
+	// A panic in a filter is not wrapped.
+	ex = recoverPanic(func() {
+		cmp.Equal(x, y, cmp.FilterValues(func(x, y []int) bool { panic("boom") }, cmp.Ignore()))
+	})
+	if ex != "boom" {
+		t.Errorf("panic value = %#v, want \"boom\"", ex)
+	}
+}
+
+func TestSprintDiff(t *testing.T) {
+	x, y := []int{1, 2, 3}, []int{1, 4, 3}
+	if got, want := cmp.SprintDiff(x, y), cmp.Diff(x, y); got != want {
+		t.Errorf("SprintDiff mismatch:\ngot:  %q\nwant: %q", got, want)
+	}
+}
+
+func TestCountDiffs(t *testing.T) {
+	type S struct {
+		A int
+		B []string
+		C map[string]int
+	}
+	x := S{A: 1, B: []string{"a", "b"}, C: map[string]int{"a": 1}}
+	y := S{A: 2, B: []string{"a", "c", "d"}, C: map[string]int{"b": 1}}
+	tests := []struct {
+		x, y interface{}
+		opts []cmp.Option
+		want int
+	}{
+		{x, x, nil, 0},
+		{x, y, nil, len(cmp.DiffStructured(x, y).Diffs)},
+		{x, y, []cmp.Option{cmpopts.IgnoreFields(S{}, "B", "C")}, 1},
+		{1, "1", nil, 1},
+	}
+	for _, tt := range tests {
+		if got := cmp.CountDiffs(tt.x, tt.y, tt.opts...); got != tt.want {
+			t.Errorf("CountDiffs(%v, %v) = %d, want %d", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestEqualContext(t *testing.T) {
+	x, y := make([]int, 10000), make([]int, 10000)
+	y[len(y)-1] = 1
+
+	ctx := context.Background()
+	if eq, err := cmp.EqualContext(ctx, x, y); eq || err != nil {
+		t.Errorf("EqualContext = (%v, %v), want (false, nil)", eq, err)
+	}
+	if d, err := cmp.DiffContext(ctx, x, y); d != cmp.Diff(x, y) || err != nil {
+		t.Errorf("DiffContext = (%q, %v), want (%q, nil)", d, err, cmp.Diff(x, y))
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if eq, err := cmp.EqualContext(canceled, x, x); eq || err != context.Canceled {
+		t.Errorf("EqualContext = (%v, %v), want (false, %v)", eq, err, context.Canceled)
+	}
+	if d, err := cmp.DiffContext(canceled, x, y); d != "" || err != context.Canceled {
+		t.Errorf("DiffContext = (%q, %v), want (\"\", %v)", d, err, context.Canceled)
+	}
+
+	// Cancel the context midway through the comparison.
+	ctx, cancel = context.WithCancel(ctx)
+	var n int
+	cancelMidway := cmp.Comparer(func(a, b int) bool {
+		if n++; n == len(x)/2 {
+			cancel()
+		}
+		return a == b
+	})
+	if eq, err := cmp.EqualContext(ctx, x, x, cancelMidway); eq || err != context.Canceled {
+		t.Errorf("EqualContext = (%v, %v), want (false, %v)", eq, err, context.Canceled)
+	}
+}
+
+func TestEqualReason(t *testing.T) {
+	x := []int{1, 2, 3, 4}
+	y := []int{1, 5, 3, 6}
+	if eq, reason := cmp.EqualReason(x, x); !eq || reason != "" {
+		t.Errorf("EqualReason = (%v, %q), want (true, \"\")", eq, reason)
+	}
+	eq, reason := cmp.EqualReason(x, y)
+	if eq || !strings.Contains(reason, "2,") || strings.Contains(reason, "6,") {
+		t.Errorf("EqualReason = (%v, %q), want (false, <only the first difference>)", eq, reason)
+	}
+
+	// The comparison must stop at the first difference.
+	type S struct{ A, B, C, D int }
+	var calls int
+	opt := cmp.Comparer(func(x, y int) bool {
+		calls++
+		return x == y
+	})
+	sx, sy := S{1, 2, 3, 4}, S{1, 5, 3, 6}
+	cmp.Equal(sx, sy, opt)
+	equalCalls := calls
+	calls = 0
+	if eq, _ := cmp.EqualReason(sx, sy, opt); eq || calls >= equalCalls {
+		t.Errorf("EqualReason made %d comparer calls, want fewer than Equal (%d)", calls, equalCalls)
+	}
+}
+
 // BenchmarkBytes benchmarks the performance of performing Equal or Diff on
 // large slices of bytes.
 func BenchmarkBytes(b *testing.B) {
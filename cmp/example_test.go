@@ -57,6 +57,23 @@ func ExampleDiff_testing() {
 	//   }
 }
 
+// Use DiffStructured to programmatically inspect the differences.
+func ExampleDiffStructured() {
+	x, y := MakeGatewayInfo()
+
+	r := cmp.DiffStructured(x, y)
+	fmt.Println("Equal:", r.Equal)
+	for _, d := range r.Diffs {
+		fmt.Printf("%#v: %v != %v\n", d.Path, d.X, d.Y)
+	}
+
+	// Output:
+	// Equal: false
+	// {cmp_test.Gateway}.IPAddress: 192.168.0.1 != 192.168.0.2
+	// {cmp_test.Gateway}.Clients[4].IPAddress: 192.168.0.219 != 192.168.0.221
+	// {cmp_test.Gateway}.Clients[5->?]: {americano 192.168.0.188 2009-11-10 23:03:05 +0000 UTC} != <invalid reflect.Value>
+}
+
 // Approximate equality for floats can be handled by defining a custom
 // comparer on floats that determines two values to be equal if they are within
 // some range of each other.
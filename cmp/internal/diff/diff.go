@@ -87,6 +87,42 @@ func (es EditScript) LenX() int { return len(es) - es.stats().NY }
 // LenY is the length of the Y list.
 func (es EditScript) LenY() int { return len(es) - es.stats().NX }
 
+// NumEqual is the number of symbol pairs that are identical in X and Y.
+func (es EditScript) NumEqual() int { return es.stats().NI }
+
+// NumDeleted is the number of symbols that only exist in X.
+func (es EditScript) NumDeleted() int { return es.stats().NX }
+
+// NumInserted is the number of symbols that only exist in Y.
+func (es EditScript) NumInserted() int { return es.stats().NY }
+
+// NumModified is the number of symbol pairs that are modifications
+// of each other.
+func (es EditScript) NumModified() int { return es.stats().NM }
+
+// Apply calls f for each operation in the edit-script in order,
+// with the indexes of the aligned symbols in X and Y.
+// The index ix is -1 for UniqueY, and the index iy is -1 for UniqueX.
+func (es EditScript) Apply(f func(e EditType, ix, iy int)) {
+	var ix, iy int
+	for _, e := range es {
+		switch e {
+		case Identity, Modified:
+			f(e, ix, iy)
+			ix++
+			iy++
+		case UniqueX:
+			f(e, ix, -1)
+			ix++
+		case UniqueY:
+			f(e, -1, iy)
+			iy++
+		default:
+			panic("invalid edit-type")
+		}
+	}
+}
+
 // EqualFunc reports whether the symbols at indexes ix and iy are equal.
 // When called by Difference, the index is guaranteed to be within nx and ny.
 type EqualFunc func(ix int, iy int) Result
@@ -447,3 +447,62 @@ func TestResult(t *testing.T) {
 		}
 	}
 }
+
+func TestPatienceDiff(t *testing.T) {
+	tests := []struct {
+		// Each character in x and y is treated as a separate line.
+		x, y string
+		want string
+	}{
+		{x: "", y: "", want: ""},
+		{x: "abc", y: "abc", want: "..."},
+		{x: "", y: "ab", want: "YY"},
+		{x: "ab", y: "", want: "XX"},
+		{x: "abc", y: "cab", want: "Y..X"},
+		{x: "a}b}", y: "a}c}b}", want: "..YY.."},
+		{x: "}a}b}", y: "}b}a}", want: ".XX.YY."},
+	}
+	for _, tt := range tests {
+		x, y := strings.Split(tt.x, ""), strings.Split(tt.y, "")
+		if got := PatienceDiff(x, y).String(); got != tt.want {
+			t.Errorf("PatienceDiff(%q, %q) = %s, want %s", tt.x, tt.y, got, tt.want)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		x, y := generateStrings(100, 0.1, 0.1, 0.1, int64(i))
+		sx, sy := strings.Split(x, ""), strings.Split(y, "")
+		es := PatienceDiff(sx, sy)
+		if es.LenX() != len(sx) || es.LenY() != len(sy) {
+			t.Fatalf("PatienceDiff(%q, %q) = %v, want lengths %d and %d", x, y, es, len(sx), len(sy))
+		}
+		var ix, iy int
+		for _, e := range es {
+			if e == Identity && sx[ix] != sy[iy] {
+				t.Fatalf("PatienceDiff(%q, %q) = %v, matches unequal lines", x, y, es)
+			}
+			if e != UniqueY {
+				ix++
+			}
+			if e != UniqueX {
+				iy++
+			}
+		}
+	}
+}
+
+func TestEditScript(t *testing.T) {
+	es := EditScript{Identity, UniqueX, Modified, UniqueY, UniqueY, Identity}
+	if got := [4]int{es.NumEqual(), es.NumDeleted(), es.NumInserted(), es.NumModified()}; got != [4]int{2, 1, 2, 1} {
+		t.Errorf("counts = %v, want [2 1 2 1]", got)
+	}
+
+	var got []string
+	es.Apply(func(e EditType, ix, iy int) {
+		got = append(got, fmt.Sprintf("%v:%d:%d", EditScript{e}, ix, iy))
+	})
+	want := []string{".:0:0", "X:1:-1", "M:2:1", "Y:-1:2", "Y:-1:3", ".:3:4"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Apply = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2026, The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package diff
+
+import "sort"
+
+// PatienceDiff reports the differences between two lists of lines
+// using the patience diff algorithm.
+//
+// The algorithm anchors on lines that occur exactly once in both lists,
+// matching the longest sequence of such lines that appear in the same order.
+// It then recursively differences the lines between each pair of anchors,
+// falling back on Difference where no unique lines remain.
+// This tends to produce an edit-script that is more readable to humans,
+// since differences are aligned on distinctive lines rather than
+// on frequent lines such as blank lines or closing braces.
+//
+// The same invariants as Difference are maintained for the edit-script.
+func PatienceDiff(x, y []string) EditScript {
+	return patienceDiff(make(EditScript, 0, max(len(x), len(y))), x, y)
+}
+
+func patienceDiff(es EditScript, x, y []string) EditScript {
+	// Match the common prefix and suffix.
+	var numPrefix, numSuffix int
+	for numPrefix < len(x) && numPrefix < len(y) && x[numPrefix] == y[numPrefix] {
+		numPrefix++
+	}
+	for numSuffix < len(x)-numPrefix && numSuffix < len(y)-numPrefix &&
+		x[len(x)-numSuffix-1] == y[len(y)-numSuffix-1] {
+		numSuffix++
+	}
+	for i := 0; i < numPrefix; i++ {
+		es = append(es, Identity)
+	}
+	x, y = x[numPrefix:len(x)-numSuffix], y[numPrefix:len(y)-numSuffix]
+
+	// Recursively difference the lines between unique anchor lines.
+	if anchors := uniqueAnchors(x, y); len(anchors) > 0 {
+		var ix, iy int
+		for _, p := range anchors {
+			es = patienceDiff(es, x[ix:p.X], y[iy:p.Y])
+			es = append(es, Identity)
+			ix, iy = p.X+1, p.Y+1
+		}
+		es = patienceDiff(es, x[ix:], y[iy:])
+	} else if len(x) > 0 || len(y) > 0 {
+		es = append(es, Difference(len(x), len(y), func(ix, iy int) Result {
+			return BoolResult(x[ix] == y[iy])
+		})...)
+	}
+
+	for i := 0; i < numSuffix; i++ {
+		es = append(es, Identity)
+	}
+	return es
+}
+
+// uniqueAnchors returns the longest sequence of points, increasing in both
+// the X and Y coordinates, where each point identifies a line that
+// occurs exactly once in both x and y.
+func uniqueAnchors(x, y []string) []point {
+	type lineCount struct{ nx, ny, iy int }
+	counts := make(map[string]lineCount)
+	for _, s := range x {
+		c := counts[s]
+		c.nx++
+		counts[s] = c
+	}
+	for i, s := range y {
+		c := counts[s]
+		c.ny++
+		c.iy = i
+		counts[s] = c
+	}
+	var points []point // sorted by X
+	for i, s := range x {
+		if c := counts[s]; c.nx == 1 && c.ny == 1 {
+			points = append(points, point{i, c.iy})
+		}
+	}
+	if len(points) == 0 {
+		return nil
+	}
+
+	// Compute the longest increasing subsequence in Y using patience sorting,
+	// where each pile is identified by the index of the point on its top.
+	var piles []int
+	prev := make([]int, len(points)) // index of the previous point in a sequence
+	for i, p := range points {
+		j := sort.Search(len(piles), func(j int) bool { return points[piles[j]].Y > p.Y })
+		prev[i] = -1
+		if j > 0 {
+			prev[i] = piles[j-1]
+		}
+		if j == len(piles) {
+			piles = append(piles, i)
+		} else {
+			piles[j] = i
+		}
+	}
+	anchors := make([]point, len(piles))
+	for i, j := len(piles)-1, piles[len(piles)-1]; i >= 0; i, j = i-1, prev[j] {
+		anchors[i] = points[j]
+	}
+	return anchors
+}
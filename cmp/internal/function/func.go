@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"runtime"
 	"strings"
+	"sync"
 )
 
 type funcType int
@@ -17,25 +18,33 @@ type funcType int
 const (
 	_ funcType = iota
 
-	tbFunc  // func(T) bool
-	ttbFunc // func(T, T) bool
-	ttiFunc // func(T, T) int
-	trbFunc // func(T, R) bool
-	tibFunc // func(T, I) bool
-	trFunc  // func(T) R
-
-	Equal             = ttbFunc // func(T, T) bool
-	EqualAssignable   = tibFunc // func(T, I) bool; encapsulates func(T, T) bool
-	Transformer       = trFunc  // func(T) R
-	ValueFilter       = ttbFunc // func(T, T) bool
-	Less              = ttbFunc // func(T, T) bool
-	Compare           = ttiFunc // func(T, T) int
-	ValuePredicate    = tbFunc  // func(T) bool
-	KeyValuePredicate = trbFunc // func(T, R) bool
+	tbFunc   // func(T) bool
+	ttbFunc  // func(T, T) bool
+	ttiFunc  // func(T, T) int
+	trbFunc  // func(T, R) bool
+	tibFunc  // func(T, I) bool
+	tiebFunc // func(T, I) (bool, error)
+	ttbsFunc // func(T, T) (bool, string)
+	trFunc   // func(T) R
+	treFunc  // func(T) (R, error)
+
+	Equal              = ttbFunc  // func(T, T) bool
+	EqualAssignable    = tibFunc  // func(T, I) bool; encapsulates func(T, T) bool
+	EqualAssignableErr = tiebFunc // func(T, I) (bool, error)
+	EqualReason        = ttbsFunc // func(T, T) (bool, string)
+	Transformer        = trFunc   // func(T) R
+	TransformerErr     = treFunc  // func(T) (R, error)
+	ValueFilter        = ttbFunc  // func(T, T) bool
+	Less               = ttbFunc  // func(T, T) bool
+	Compare            = ttiFunc  // func(T, T) int
+	ValuePredicate     = tbFunc   // func(T) bool
+	KeyValuePredicate  = trbFunc  // func(T, R) bool
 )
 
 var boolType = reflect.TypeOf(true)
 var intType = reflect.TypeOf(0)
+var stringType = reflect.TypeOf("")
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
 
 // IsType reports whether the reflect.Type is of the specified function type.
 func IsType(t reflect.Type, ft funcType) bool {
@@ -64,19 +73,45 @@ func IsType(t reflect.Type, ft funcType) bool {
 		if ni == 2 && no == 1 && t.In(0).AssignableTo(t.In(1)) && t.Out(0) == boolType {
 			return true
 		}
+	case tiebFunc: // func(T, I) (bool, error)
+		if ni == 2 && no == 2 && t.In(0).AssignableTo(t.In(1)) && t.Out(0) == boolType && t.Out(1) == errorType {
+			return true
+		}
+	case ttbsFunc: // func(T, T) (bool, string)
+		if ni == 2 && no == 2 && t.In(0) == t.In(1) && t.Out(0) == boolType && t.Out(1) == stringType {
+			return true
+		}
 	case trFunc: // func(T) R
 		if ni == 1 && no == 1 {
 			return true
 		}
+	case treFunc: // func(T) (R, error)
+		if ni == 1 && no == 2 && t.Out(1) == errorType {
+			return true
+		}
 	}
 	return false
 }
 
 var lastIdentRx = regexp.MustCompile(`[_\p{L}][_\p{L}\p{N}]*$`)
 
+// nameCache caches the result of NameOf keyed by the function pointer.
+// Entries are never evicted since the set of functions in a program is fixed.
+var nameCache sync.Map // map[uintptr]string
+
 // NameOf returns the name of the function value.
 func NameOf(v reflect.Value) string {
-	fnc := runtime.FuncForPC(v.Pointer())
+	pc := v.Pointer()
+	if name, ok := nameCache.Load(pc); ok {
+		return name.(string)
+	}
+	name := nameOf(pc)
+	nameCache.Store(pc, name)
+	return name
+}
+
+func nameOf(pc uintptr) string {
+	fnc := runtime.FuncForPC(pc)
 	if fnc == nil {
 		return "<unknown>"
 	}
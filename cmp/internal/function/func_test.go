@@ -49,3 +49,10 @@ func TestNameOf(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkNameOf(b *testing.B) {
+	v := reflect.ValueOf((*myType).PointerMethod)
+	for i := 0; i < b.N; i++ {
+		NameOf(v)
+	}
+}
@@ -7,6 +7,7 @@ package value
 import (
 	"reflect"
 	"strconv"
+	"strings"
 )
 
 var anyType = reflect.TypeOf((*interface{})(nil)).Elem()
@@ -29,16 +30,24 @@ func appendTypeName(b []byte, t reflect.Type, qualified, elideFunc bool) []byte
 
 	// Named type.
 	if t.Name() != "" {
+		// The name of an instantiated generic type includes the type arguments
+		// (e.g., "Map[string,example.com/pkg.T]"), where named type arguments
+		// are always qualified by their full package path.
+		name, args := t.Name(), ""
+		if i := strings.IndexByte(name, '['); i >= 0 {
+			name, args = name[:i], name[i:]
+		}
 		if qualified && t.PkgPath() != "" {
 			b = append(b, '"')
 			b = append(b, t.PkgPath()...)
 			b = append(b, '"')
 			b = append(b, '.')
-			b = append(b, t.Name()...)
+			b = append(b, name...)
 		} else {
-			b = append(b, t.String()...)
+			b = append(b, strings.TrimSuffix(t.String(), t.Name())...)
+			b = append(b, name...)
 		}
-		return b
+		return appendTypeArgs(b, args, qualified)
 	}
 
 	// Unnamed type.
@@ -162,3 +171,49 @@ func appendTypeName(b []byte, t reflect.Type, qualified, elideFunc bool) []byte
 	}
 	return b
 }
+
+// appendTypeArgs appends the type arguments of an instantiated generic type
+// as formatted by the reflect package, where each named type is qualified
+// by its full package path (e.g., "example.com/pkg.T").
+// Such names are reformatted to be consistent with appendTypeName.
+func appendTypeArgs(b []byte, args string, qualified bool) []byte {
+	const delims = "[](){},;* \t"
+	for len(args) > 0 {
+		switch {
+		case args[0] == '"' || args[0] == '`':
+			// Copy over any quoted string (e.g., a struct tag) as is.
+			n := len(args)
+			if q, err := strconv.QuotedPrefix(args); err == nil {
+				n = len(q)
+			}
+			b, args = append(b, args[:n]...), args[n:]
+		case strings.IndexByte(delims, args[0]) >= 0:
+			b, args = append(b, args[0]), args[1:]
+		default:
+			// Reformat any identifier qualified by a package path.
+			n := strings.IndexAny(args, delims)
+			if n < 0 {
+				n = len(args)
+			}
+			word := args[:n]
+			args = args[n:]
+			i := strings.LastIndexByte(word, '.')
+			if i < 0 {
+				b = append(b, word...)
+				continue
+			}
+			pkgPath, ident := word[:i], word[i+1:]
+			if qualified {
+				b = append(b, '"')
+				b = append(b, pkgPath...)
+				b = append(b, '"')
+			} else {
+				// Assume that the package name is the last path element.
+				b = append(b, pkgPath[strings.LastIndexByte(pkgPath, '/')+1:]...)
+			}
+			b = append(b, '.')
+			b = append(b, ident...)
+		}
+	}
+	return b
+}
@@ -12,6 +12,10 @@ import (
 
 type Named struct{}
 
+type Generic[T any] struct{}
+
+type Pair[K comparable, V any] struct{}
+
 var pkgPath = reflect.TypeOf(Named{}).PkgPath()
 
 func TestTypeString(t *testing.T) {
@@ -124,6 +128,21 @@ func TestTypeString(t *testing.T) {
 			F5(...Named)
 		})(nil),
 		want: "*interface{ F1(); F2($PackagePath.Named); F3() $PackagePath.Named; F4(int, $PackagePath.Named) (int, error); F5(...$PackagePath.Named) }",
+	}, {
+		in:   Generic[int]{},
+		want: "$PackagePath.Generic[int]",
+	}, {
+		in:   Generic[Named]{},
+		want: "$PackagePath.Generic[$PackagePath.Named]",
+	}, {
+		in:   Generic[map[string][]*Named]{},
+		want: "$PackagePath.Generic[map[string][]*$PackagePath.Named]",
+	}, {
+		in:   Pair[Named, Generic[Named]]{},
+		want: "$PackagePath.Pair[$PackagePath.Named,$PackagePath.Generic[$PackagePath.Named]]",
+	}, {
+		in:   []Generic[Named](nil),
+		want: "[]$PackagePath.Generic[$PackagePath.Named]",
 	}}
 
 	for _, tt := range tests {
@@ -5,10 +5,12 @@
 package cmp
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/google/go-cmp/cmp/internal/function"
 )
@@ -17,8 +19,9 @@ import (
 // the fundamental Option functions ([Ignore], [Transformer], and [Comparer]),
 // configure how equality is determined.
 //
-// The fundamental options may be composed with filters ([FilterPath] and
-// [FilterValues]) to control the scope over which they are applied.
+// The fundamental options may be composed with filters ([FilterPath],
+// [FilterValues], [FilterValuesWithPath], and [FilterField]) to control
+// the scope over which they are applied.
 //
 // The [github.com/google/go-cmp/cmp/cmpopts] package provides helper functions
 // for creating options that may be used with [Equal] and [Diff].
@@ -46,7 +49,7 @@ type applicableOption interface {
 // coreOption represents the following types:
 //
 //	Fundamental: ignore | validator | *comparer | *transformer
-//	Filters:     *pathFilter | *valuesFilter
+//	Filters:     *pathFilter | *valuesFilter | *pathValuesFilter | *fieldFilter | *notFilter
 type coreOption interface {
 	Option
 	isCore()
@@ -83,6 +86,11 @@ func (opts Options) filter(s *state, t reflect.Type, vx, vy reflect.Value) (out
 			}
 		}
 	}
+	if conflicts, ok := out.(Options); ok {
+		if cm := mostSpecificComparer(flattenOptions(nil, conflicts)); cm != nil {
+			return cm // Prefer the comparer on the most specific type
+		}
+	}
 	return out
 }
 
@@ -105,6 +113,61 @@ func (opts Options) String() string {
 	return fmt.Sprintf("Options{%s}", strings.Join(ss, ", "))
 }
 
+// Validate reports obvious misconfigurations that would otherwise cause
+// [Equal] or [Diff] to panic. It reports an error for every [Ignore],
+// [Transformer], or [Comparer] that is not restricted by any filter and
+// would therefore apply to all values, and for every [Transformer] or
+// [Comparer] that applies to exactly the same type as an earlier one and
+// would therefore be ambiguous for every value of that type.
+//
+// Options wrapped by [FilterPath] or [FilterValues] are not inspected further
+// since whether they apply depends on the values being compared.
+// Thus, a nil error does not guarantee that comparison will not panic.
+func (opts Options) Validate() error {
+	var errs []error
+	types := make(map[reflect.Type]Option)
+	checkType := func(t reflect.Type, opt Option) {
+		switch prev, ok := types[t]; {
+		case t == nil:
+			errs = append(errs, fmt.Errorf("cannot use an unfiltered option: %v", opt))
+		case ok:
+			errs = append(errs, fmt.Errorf("ambiguous set of applicable options for type %v: %v and %v", t, prev, opt))
+		default:
+			types[t] = opt
+		}
+	}
+	var validate func(Options)
+	validate = func(opts Options) {
+		for _, opt := range opts {
+			switch opt := opt.(type) {
+			case Options:
+				validate(opt)
+			case ignore:
+				errs = append(errs, fmt.Errorf("cannot use an unfiltered option: %v", opt))
+			case *comparer:
+				checkType(opt.typ, opt)
+			case *transformer:
+				checkType(opt.typ, opt)
+			}
+		}
+	}
+	validate(opts)
+	return errors.Join(errs...)
+}
+
+// FilterPathString returns a new [Option] where opt is only evaluated if
+// the current [Path] in the value tree matches the pattern,
+// as reported by [Path.MatchesGlob]. The pattern is parsed once
+// when the option is constructed. It panics if the pattern is malformed.
+//
+// For example, the following ignores any field called ID at any depth:
+//
+//	cmp.FilterPathString("**.ID", cmp.Ignore())
+func FilterPathString(pattern string, opt Option) Option {
+	toks := mustParsePathPattern(pattern)
+	return FilterPath(func(p Path) bool { return matchPathPattern(toks, p.globSteps()) }, opt)
+}
+
 // FilterPath returns a new [Option] where opt is only evaluated if filter f
 // returns true for the current [Path] in the value tree.
 //
@@ -132,12 +195,16 @@ type pathFilter struct {
 }
 
 func (f pathFilter) filter(s *state, t reflect.Type, vx, vy reflect.Value) applicableOption {
-	if f.fnc(s.curPath) {
+	if f.match(s, t, vx, vy) {
 		return f.opt.filter(s, t, vx, vy)
 	}
 	return nil
 }
 
+func (f pathFilter) match(s *state, _ reflect.Type, _, _ reflect.Value) bool {
+	return f.fnc(s.curPath)
+}
+
 func (f pathFilter) String() string {
 	return fmt.Sprintf("FilterPath(%s, %v)", function.NameOf(reflect.ValueOf(f.fnc)), f.opt)
 }
@@ -179,19 +246,180 @@ type valuesFilter struct {
 }
 
 func (f valuesFilter) filter(s *state, t reflect.Type, vx, vy reflect.Value) applicableOption {
-	if !vx.IsValid() || !vx.CanInterface() || !vy.IsValid() || !vy.CanInterface() {
-		return nil
-	}
-	if (f.typ == nil || t.AssignableTo(f.typ)) && s.callTTBFunc(f.fnc, vx, vy) {
+	if f.match(s, t, vx, vy) {
 		return f.opt.filter(s, t, vx, vy)
 	}
 	return nil
 }
 
+func (f valuesFilter) match(s *state, t reflect.Type, vx, vy reflect.Value) bool {
+	if !vx.IsValid() || !vx.CanInterface() || !vy.IsValid() || !vy.CanInterface() {
+		return false
+	}
+	return (f.typ == nil || t.AssignableTo(f.typ)) && s.callTTBFunc(f.fnc, vx, vy)
+}
+
 func (f valuesFilter) String() string {
 	return fmt.Sprintf("FilterValues(%s, %v)", function.NameOf(f.fnc), f.opt)
 }
 
+// FilterValuesWithPath returns a new [Option] where opt is only evaluated if
+// filter f returns true for the current [Path] in the value tree and
+// the current pair of values being compared. If either value is invalid
+// or cannot be converted to an interface (e.g., an unexported field), then
+// this filter implicitly returns false.
+//
+// The filter function must be symmetric and deterministic
+// with regard to the values, as described by [FilterValues].
+//
+// The option passed in may be an [Ignore], [Transformer], [Comparer], [Options], or
+// a previously filtered [Option].
+func FilterValuesWithPath(f func(p Path, x, y interface{}) bool, opt Option) Option {
+	if f == nil {
+		panic("invalid path values filter function")
+	}
+	if opt := normalizeOption(opt); opt != nil {
+		return &pathValuesFilter{fnc: f, opt: opt}
+	}
+	return nil
+}
+
+type pathValuesFilter struct {
+	core
+	fnc func(Path, interface{}, interface{}) bool
+	opt Option
+}
+
+func (f pathValuesFilter) filter(s *state, t reflect.Type, vx, vy reflect.Value) applicableOption {
+	if f.match(s, t, vx, vy) {
+		return f.opt.filter(s, t, vx, vy)
+	}
+	return nil
+}
+
+func (f pathValuesFilter) match(s *state, _ reflect.Type, vx, vy reflect.Value) bool {
+	if !vx.IsValid() || !vx.CanInterface() || !vy.IsValid() || !vy.CanInterface() {
+		return false
+	}
+	return f.fnc(s.curPath, vx.Interface(), vy.Interface())
+}
+
+func (f pathValuesFilter) String() string {
+	return fmt.Sprintf("FilterValuesWithPath(%s, %v)", function.NameOf(reflect.ValueOf(f.fnc)), f.opt)
+}
+
+// FilterField returns a new [Option] where opt is only evaluated on the
+// field called name within a single struct type, and on all values nested
+// within that field. The struct type is specified by passing in a value of
+// that type. The field must be declared directly within the struct type;
+// fields forwarded due to struct embedding are not permitted.
+//
+// The option passed in may be an [Ignore], [Transformer], [Comparer], [Options], or
+// a previously filtered [Option].
+func FilterField(typ interface{}, name string, opt Option) Option {
+	t := reflect.TypeOf(typ)
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("%T must be a non-pointer struct", typ))
+	}
+	idx := -1
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Name == name {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		panic(fmt.Sprintf("%T has no field %q", typ, name))
+	}
+	if opt := normalizeOption(opt); opt != nil {
+		return &fieldFilter{typ: t, idx: idx, opt: opt}
+	}
+	return nil
+}
+
+type fieldFilter struct {
+	core
+	typ reflect.Type // The parent struct type
+	idx int          // The field index within typ
+	opt Option
+}
+
+func (f fieldFilter) filter(s *state, t reflect.Type, vx, vy reflect.Value) applicableOption {
+	if f.match(s, t, vx, vy) {
+		return f.opt.filter(s, t, vx, vy)
+	}
+	return nil
+}
+
+func (f fieldFilter) match(s *state, _ reflect.Type, _, _ reflect.Value) bool {
+	for i := 1; i < len(s.curPath); i++ {
+		if sf, ok := s.curPath[i].(StructField); ok && sf.Index() == f.idx && s.curPath[i-1].Type() == f.typ {
+			return true
+		}
+	}
+	return false
+}
+
+func (f fieldFilter) String() string {
+	return fmt.Sprintf("FilterField(%v, %q, %v)", f.typ, f.typ.Field(f.idx).Name, f.opt)
+}
+
+// Not returns a new [Option] that inverts the filter of opt, such that
+// the option wrapped by the filter is only evaluated where the filter
+// would otherwise not apply. For example, the following applies a comparer
+// everywhere except within the field MyStruct.Field:
+//
+//	cmp.Not(cmp.FilterField(MyStruct{}, "Field", cmp.Comparer(f)))
+//
+// The option passed in must be the result of [FilterPath], [FilterValues],
+// [FilterValuesWithPath], [FilterField], or Not.
+// Note that [FilterValues] and [FilterValuesWithPath] never match invalid or
+// unexported values, so the inverted filter always matches such values.
+func Not(opt Option) Option {
+	var inner Option
+	switch f := opt.(type) {
+	case *pathFilter:
+		inner = f.opt
+	case *valuesFilter:
+		inner = f.opt
+	case *pathValuesFilter:
+		inner = f.opt
+	case *fieldFilter:
+		inner = f.opt
+	case *notFilter:
+		inner = f.opt
+	default:
+		panic(fmt.Sprintf("invalid option type: %T", opt))
+	}
+	return &notFilter{fnc: opt.(filterMatcher), opt: inner}
+}
+
+// filterMatcher is implemented by every filter option.
+type filterMatcher interface {
+	// match reports whether the filter applies to the current values.
+	match(s *state, t reflect.Type, vx, vy reflect.Value) bool
+}
+
+type notFilter struct {
+	core
+	fnc filterMatcher
+	opt Option
+}
+
+func (f notFilter) filter(s *state, t reflect.Type, vx, vy reflect.Value) applicableOption {
+	if f.match(s, t, vx, vy) {
+		return f.opt.filter(s, t, vx, vy)
+	}
+	return nil
+}
+
+func (f notFilter) match(s *state, t reflect.Type, vx, vy reflect.Value) bool {
+	return !f.fnc.match(s, t, vx, vy)
+}
+
+func (f notFilter) String() string {
+	return fmt.Sprintf("Not(%v)", f.fnc)
+}
+
 // Ignore is an [Option] that causes all comparisons to be ignored.
 // This value is intended to be combined with [FilterPath] or [FilterValues].
 // It is an error to pass an unfiltered Ignore option to [Equal].
@@ -264,12 +492,35 @@ const identRx = `[_\p{L}][_\p{L}\p{N}]*`
 
 var identsRx = regexp.MustCompile(`^` + identRx + `(\.` + identRx + `)*$`)
 
+// transformerNames caches the default name of a transformer keyed by the
+// function pointer. As with [function.NameOf], entries are never evicted
+// since the set of functions in a program is fixed.
+var transformerNames sync.Map // map[uintptr]string
+
+// defaultTransformerName returns the name of the transformer function v
+// if it is a valid qualified identifier, and otherwise a placeholder name.
+func defaultTransformerName(v reflect.Value) string {
+	pc := v.Pointer()
+	if name, ok := transformerNames.Load(pc); ok {
+		return name.(string)
+	}
+	name := function.NameOf(v)
+	if !identsRx.MatchString(name) {
+		name = "λ" // Lambda-symbol as placeholder name
+	}
+	transformerNames.Store(pc, name)
+	return name
+}
+
 // Transformer returns an [Option] that applies a transformation function that
 // converts values of a certain type into that of another.
 //
 // The transformer f must be a function "func(T) R" that converts values of
 // type T to those of type R and is implicitly filtered to input values
 // assignable to T. The transformer must not mutate T in any way.
+// The transformer may also be a function "func(T) (R, error)",
+// in which case a non-nil error causes a panic, since there is
+// no meaningful result to compare.
 //
 // To help prevent some cases of infinite recursive cycles applying the
 // same transform to the output of itself (e.g., in the case where the
@@ -287,14 +538,11 @@ var identsRx = regexp.MustCompile(`^` + identRx + `(\.` + identRx + `)*$`)
 // If empty, an arbitrary name is used.
 func Transformer(name string, f interface{}) Option {
 	v := reflect.ValueOf(f)
-	if !function.IsType(v.Type(), function.Transformer) || v.IsNil() {
+	if (!function.IsType(v.Type(), function.Transformer) && !function.IsType(v.Type(), function.TransformerErr)) || v.IsNil() {
 		panic(fmt.Sprintf("invalid transformer function: %T", f))
 	}
 	if name == "" {
-		name = function.NameOf(v)
-		if !identsRx.MatchString(name) {
-			name = "λ" // Lambda-symbol as placeholder name
-		}
+		name = defaultTransformerName(v)
 	} else if !identsRx.MatchString(name) {
 		panic(fmt.Sprintf("invalid name: %q", name))
 	}
@@ -309,7 +557,7 @@ type transformer struct {
 	core
 	name string
 	typ  reflect.Type  // T
-	fnc  reflect.Value // func(T) R
+	fnc  reflect.Value // func(T) R or func(T) (R, error)
 }
 
 func (tr *transformer) isFiltered() bool { return tr.typ != nil }
@@ -346,7 +594,11 @@ func (tr transformer) String() string {
 // The comparer f must be a function "func(T, T) bool" and is implicitly
 // filtered to input values assignable to T. If T is an interface, it is
 // possible that f is called with two values of different concrete types that
-// both implement T.
+// both implement T. If multiple comparers apply to the same values,
+// then the comparer on the most specific type is used, where T is more
+// specific than R if T is assignable to R, but R is not assignable to T.
+// If f panics, then the panic is propagated as a [*ComparerPanic] that
+// records the path to the values being compared.
 //
 // The equality function must be:
 //   - Symmetric: equal(x, y) == equal(y, x)
@@ -364,10 +616,69 @@ func Comparer(f interface{}) Option {
 	return cm
 }
 
+// ComparerWithReason is like [Comparer], but f also returns a reason
+// explaining why x and y are unequal, which is shown alongside the values
+// in the report produced by [Diff] and available to a [Reporter] through
+// [Result.Reason]. The reason is ignored if f reports equality.
+func ComparerWithReason(f interface{}) Option {
+	v := reflect.ValueOf(f)
+	if !function.IsType(v.Type(), function.EqualReason) || v.IsNil() {
+		panic(fmt.Sprintf("invalid comparer function: %T", f))
+	}
+	cm := &comparer{fnc: v}
+	if ti := v.Type().In(0); ti.Kind() != reflect.Interface || ti.NumMethod() > 0 {
+		cm.typ = ti
+	}
+	return cm
+}
+
 type comparer struct {
 	core
 	typ reflect.Type  // T
-	fnc reflect.Value // func(T, T) bool
+	fnc reflect.Value // func(T, T) bool or func(T, T) (bool, string)
+}
+
+// mostSpecificComparer returns the comparer in opts that is more specific
+// than every other option in opts. Otherwise, it returns nil.
+// The result does not depend on the order of opts.
+func mostSpecificComparer(opts Options) *comparer {
+	for i, opt := range opts {
+		cm, ok := opt.(*comparer)
+		if !ok {
+			continue
+		}
+		isMost := true
+		for j, other := range opts {
+			if i != j && moreSpecificComparer(cm, other) != cm {
+				isMost = false
+				break
+			}
+		}
+		if isMost {
+			return cm
+		}
+	}
+	return nil
+}
+
+// moreSpecificComparer returns whichever of x and y is a comparer
+// on a type that is assignable to the type of the other comparer,
+// but not vice-versa. Otherwise, it returns nil.
+func moreSpecificComparer(x, y Option) *comparer {
+	cx, ok1 := x.(*comparer)
+	cy, ok2 := y.(*comparer)
+	if !ok1 || !ok2 {
+		return nil
+	}
+	tx, ty := cx.fnc.Type().In(0), cy.fnc.Type().In(0)
+	switch {
+	case tx.AssignableTo(ty) && !ty.AssignableTo(tx):
+		return cx
+	case ty.AssignableTo(tx) && !tx.AssignableTo(ty):
+		return cy
+	default:
+		return nil
+	}
 }
 
 func (cm *comparer) isFiltered() bool { return cm.typ != nil }
@@ -380,8 +691,40 @@ func (cm *comparer) filter(_ *state, t reflect.Type, _, _ reflect.Value) applica
 }
 
 func (cm *comparer) apply(s *state, vx, vy reflect.Value) {
-	eq := s.callTTBFunc(cm.fnc, vx, vy)
-	s.report(eq, reportByFunc)
+	eq, reason := s.callTTBSFunc(cm.fnc, vx, vy, func(f, x, y reflect.Value) (bool, string) {
+		defer func() {
+			if ex := recover(); ex != nil {
+				if _, ok := ex.(*ComparerPanic); !ok {
+					ex = &ComparerPanic{Path: s.curPath.clone(), Value: ex}
+				}
+				panic(ex)
+			}
+		}()
+		return callEqual(f, x, y)
+	})
+	s.reportReason(eq, reportByFunc, reason)
+}
+
+// ComparerPanic is the value that [Equal] and [Diff] panic with
+// when a [Comparer] function panics. A panic that occurs within a nested call
+// to Equal or Diff from a Comparer function is not wrapped again.
+type ComparerPanic struct {
+	// Path is the path to the values passed to the Comparer function.
+	Path Path
+
+	// Value is the original value that the Comparer function panicked with.
+	Value interface{}
+}
+
+func (p *ComparerPanic) Error() string {
+	return fmt.Sprintf("panic at %#v: %v", p.Path, p.Value)
+}
+
+// Unwrap returns the original panic value if it is an error.
+// Otherwise, it returns nil.
+func (p *ComparerPanic) Unwrap() error {
+	err, _ := p.Value.(error)
+	return err
 }
 
 func (cm comparer) String() string {
@@ -442,8 +785,9 @@ func AllowUnexported(types ...interface{}) Option {
 // Result represents the comparison result for a single node and
 // is provided by cmp when calling Report (see [Reporter]).
 type Result struct {
-	_     [0]func() // Make Result incomparable
-	flags resultFlags
+	_      [0]func() // Make Result incomparable
+	flags  resultFlags
+	reason string
 }
 
 // Equal reports whether the node was determined to be equal or not.
@@ -473,6 +817,13 @@ func (r Result) ByCycle() bool {
 	return r.flags&reportByCycle != 0
 }
 
+// Reason reports why the node was determined to be unequal
+// as explained by a [ComparerWithReason] function.
+// It is empty if no reason was provided.
+func (r Result) Reason() string {
+	return r.reason
+}
+
 type resultFlags uint
 
 const (
@@ -529,6 +880,142 @@ func (reporter) filter(_ *state, _ reflect.Type, _, _ reflect.Value) applicableO
 	panic("not implemented")
 }
 
+// WithSummary returns an [Option] that appends a summary line to the report
+// produced by [Diff] with the number of insertions, deletions, and
+// modifications (e.g., "// 1 insertion, 0 deletions, 2 modifications").
+// An insertion or deletion is a slice element or map entry that only exists
+// in y or x, respectively, and is counted once regardless of its contents.
+// A modification is a leaf value that differs between x and y.
+// This option has no effect on [Equal].
+func WithSummary() Option {
+	return reportOption(func(o *reportOptions) { o.Summary = true })
+}
+
+// WithMaxDiffs returns an [Option] that limits the report produced by [Diff]
+// to the first n differences, where each difference is a leaf value that
+// differs between x and y. If any differences are omitted, then the report
+// ends with a line indicating how many more differences exist.
+// The limit counts differing values, not lines of the report.
+// A string or slice that is printed as a line-based or byte-based diff
+// is always printed in full, even if that shows more than n differing lines.
+// It panics if n is not positive. This option has no effect on [Equal].
+func WithMaxDiffs(n int) Option {
+	if n <= 0 {
+		panic(fmt.Sprintf("invalid maximum number of differences: %d", n))
+	}
+	return reportOption(func(o *reportOptions) { o.MaxDiffs = n })
+}
+
+// WithColor returns an [Option] that highlights the report produced by [Diff]
+// using ANSI escape codes, where lines removed from x are red and
+// lines inserted from y are green. The escape codes are always emitted,
+// so this should only be used when the output is known to be a terminal.
+// This option has no effect on [Equal].
+func WithColor() Option {
+	return reportOption(func(o *reportOptions) { o.Color = true })
+}
+
+// WithContext returns an [Option] that sets the number of surrounding
+// equal records printed around each difference in the report produced by [Diff].
+// Records are the elements of a slice, the entries of a map, or the fields
+// of a struct. If n is zero, then no equal records are printed,
+// and if n is -1, then all equal records are printed.
+// By default, two equal records are printed. It panics if n is less than -1.
+// This option has no effect on [Equal].
+func WithContext(n int) Option {
+	if n < -1 {
+		panic(fmt.Sprintf("invalid number of context records: %d", n))
+	}
+	return reportOption(func(o *reportOptions) { o.NumContextRecords = n })
+}
+
+// StringDiffAlgorithm is an algorithm for differencing the lines of
+// multi-line strings in the report produced by [Diff].
+type StringDiffAlgorithm int
+
+const (
+	// DefaultStringDiff is a greedy algorithm that favors performance over
+	// producing a minimal or readable set of differences.
+	DefaultStringDiff StringDiffAlgorithm = iota
+
+	// PatienceStringDiff is the patience diff algorithm, which first aligns
+	// the lines that occur exactly once in both strings. It usually produces
+	// a more readable report when unique lines are far apart.
+	PatienceStringDiff
+)
+
+// WithStringDiffAlgorithm returns an [Option] that selects the algorithm
+// used to difference the lines of multi-line strings in the report
+// produced by [Diff]. It panics if algo is not a known algorithm.
+// This option has no effect on [Equal].
+func WithStringDiffAlgorithm(algo StringDiffAlgorithm) Option {
+	if algo != DefaultStringDiff && algo != PatienceStringDiff {
+		panic(fmt.Sprintf("invalid string diff algorithm: %d", algo))
+	}
+	return reportOption(func(o *reportOptions) { o.StringDiffAlgorithm = algo })
+}
+
+// SideBySide returns an [Option] that formats each modified leaf value in the
+// report produced by [Diff] on a single line prefixed with a "~",
+// where the value from x and the value from y are separated by an arrow.
+// For example, a struct field that changed from 1 to 2 is formatted as:
+//
+//	~ 	Field: 1 → 2,
+//
+// Values that span multiple lines are still formatted as separate removed
+// and inserted records. This option has no effect on [Equal].
+func SideBySide() Option {
+	return reportOption(func(o *reportOptions) { o.SideBySide = true })
+}
+
+// WithUnifiedDiff returns an [Option] that formats the differences between
+// multi-line strings in the report produced by [Diff] as a unified diff,
+// with "--- a" and "+++ b" headers and "@@ -l,s +l,s @@" hunks,
+// where a is the string from x and b is the string from y.
+// Each hunk has the number of context lines configured by [WithContext].
+// The unified diff is delimited by triple quotes (""") and may be extracted
+// from the report for use with tools such as patch(1).
+// Strings with lines containing non-printable characters are formatted
+// as usual. This option has no effect on [Equal].
+func WithUnifiedDiff() Option {
+	return reportOption(func(o *reportOptions) { o.UnifiedDiff = true })
+}
+
+// WithoutTypes returns an [Option] that omits all type names from the report
+// produced by [Diff], such that a struct is formatted as {Field: value}
+// rather than as MyStruct{Field: value}. Values of different types that
+// are formatted identically are indistinguishable in such a report.
+// This option has no effect on [Equal].
+func WithoutTypes() Option {
+	return reportOption(func(o *reportOptions) { o.ElideTypes = true })
+}
+
+// WithVerbosity returns an [Option] that sets the verbosity level
+// of the report produced by [Diff], where the level is between 0 and 3:
+//
+//   - Level 0 prints the least amount of detail, where deeply nested values
+//     and long lists of records are elided sooner than usual.
+//   - Level 1 is the default.
+//   - Level 2 prints more nested values and avoids calling methods like
+//     error.Error or fmt.Stringer.String to format values.
+//   - Level 3 additionally prints the address of pointers and
+//     the fully qualified name of types.
+//
+// It panics if level is not within that range.
+// This option has no effect on [Equal].
+func WithVerbosity(level int) Option {
+	if level < 0 || level > 3 {
+		panic(fmt.Sprintf("invalid verbosity level: %d", level))
+	}
+	return reportOption(func(o *reportOptions) { o.Verbosity = level })
+}
+
+type reportOption func(*reportOptions)
+
+func (reportOption) filter(_ *state, _ reflect.Type, _, _ reflect.Value) applicableOption {
+	panic("not implemented")
+}
+
 // normalizeOption normalizes the input options such that all Options groups
 // are flattened and groups with a single element are reduced to that element.
 // Only coreOptions and Options containing coreOptions are allowed.
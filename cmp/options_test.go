@@ -5,6 +5,7 @@
 package cmp
 
 import (
+	"fmt"
 	"io"
 	"reflect"
 	"strings"
@@ -62,6 +63,20 @@ func TestOptionPanic(t *testing.T) {
 		fnc:       Comparer,
 		args:      []interface{}{func(x, y io.Reader) myBool { return true }},
 		wantPanic: "invalid comparer function",
+	}, {
+		label: "ComparerWithReason",
+		fnc:   ComparerWithReason,
+		args:  []interface{}{func(x, y io.Reader) (bool, string) { return true, "" }},
+	}, {
+		label:     "ComparerWithReason",
+		fnc:       ComparerWithReason,
+		args:      []interface{}{func(x, y io.Reader) bool { return true }},
+		wantPanic: "invalid comparer function",
+	}, {
+		label:     "ComparerWithReason",
+		fnc:       ComparerWithReason,
+		args:      []interface{}{func(x, y io.Reader) (bool, error) { return true, nil }},
+		wantPanic: "invalid comparer function",
 	}, {
 		label:     "Comparer",
 		fnc:       Comparer,
@@ -99,6 +114,15 @@ func TestOptionPanic(t *testing.T) {
 		fnc:       Transformer,
 		args:      []interface{}{"", (func(int) uint)(nil)},
 		wantPanic: "invalid transformer function",
+	}, {
+		label: "Transformer",
+		fnc:   Transformer,
+		args:  []interface{}{"", func(string) (int, error) { return 0, nil }},
+	}, {
+		label:     "Transformer",
+		fnc:       Transformer,
+		args:      []interface{}{"", func(string) (int, bool) { return 0, true }},
+		wantPanic: "invalid transformer function",
 	}, {
 		label: "Transformer",
 		fnc:   Transformer,
@@ -121,6 +145,15 @@ func TestOptionPanic(t *testing.T) {
 		fnc:       FilterPath,
 		args:      []interface{}{(func(Path) bool)(nil), Ignore()},
 		wantPanic: "invalid path filter function",
+	}, {
+		label: "FilterPathString",
+		fnc:   FilterPathString,
+		args:  []interface{}{"**.Foo[*]", Ignore()},
+	}, {
+		label:     "FilterPathString",
+		fnc:       FilterPathString,
+		args:      []interface{}{"Foo[", Ignore()},
+		wantPanic: "invalid path pattern",
 	}, {
 		label: "FilterPath",
 		fnc:   FilterPath,
@@ -181,6 +214,98 @@ func TestOptionPanic(t *testing.T) {
 		fnc:       FilterValues,
 		args:      []interface{}{func(int, int) bool { return true }, Options{Ignore(), Reporter(&defaultReporter{})}},
 		wantPanic: "invalid option type",
+	}, {
+		label:     "FilterValuesWithPath",
+		fnc:       FilterValuesWithPath,
+		args:      []interface{}{(func(Path, interface{}, interface{}) bool)(nil), Ignore()},
+		wantPanic: "invalid path values filter function",
+	}, {
+		label: "FilterValuesWithPath",
+		fnc:   FilterValuesWithPath,
+		args:  []interface{}{func(Path, interface{}, interface{}) bool { return true }, Ignore()},
+	}, {
+		label:     "FilterValuesWithPath",
+		fnc:       FilterValuesWithPath,
+		args:      []interface{}{func(Path, interface{}, interface{}) bool { return true }, Reporter(&defaultReporter{})},
+		wantPanic: "invalid option type",
+	}, {
+		label: "WithMaxDiffs",
+		fnc:   WithMaxDiffs,
+		args:  []interface{}{1},
+	}, {
+		label:     "WithMaxDiffs",
+		fnc:       WithMaxDiffs,
+		args:      []interface{}{0},
+		wantPanic: "invalid maximum number of differences",
+	}, {
+		label: "WithContext",
+		fnc:   WithContext,
+		args:  []interface{}{-1},
+	}, {
+		label:     "WithContext",
+		fnc:       WithContext,
+		args:      []interface{}{-2},
+		wantPanic: "invalid number of context records",
+	}, {
+		label: "WithStringDiffAlgorithm",
+		fnc:   WithStringDiffAlgorithm,
+		args:  []interface{}{PatienceStringDiff},
+	}, {
+		label:     "WithStringDiffAlgorithm",
+		fnc:       WithStringDiffAlgorithm,
+		args:      []interface{}{StringDiffAlgorithm(-1)},
+		wantPanic: "invalid string diff algorithm",
+	}, {
+		label: "WithVerbosity",
+		fnc:   WithVerbosity,
+		args:  []interface{}{3},
+	}, {
+		label:     "WithVerbosity",
+		fnc:       WithVerbosity,
+		args:      []interface{}{4},
+		wantPanic: "invalid verbosity level",
+	}, {
+		label:     "FilterPath",
+		fnc:       FilterPath,
+		args:      []interface{}{func(Path) bool { return true }, WithSummary()},
+		wantPanic: "invalid option type",
+	}, {
+		label: "FilterField",
+		fnc:   FilterField,
+		args:  []interface{}{ts.StructA{}, "X", Ignore()},
+	}, {
+		label: "FilterField",
+		fnc:   FilterField,
+		args:  []interface{}{ts.StructA1{}, "StructA", Ignore()},
+	}, {
+		label:     "FilterField",
+		fnc:       FilterField,
+		args:      []interface{}{0, "X", Ignore()},
+		wantPanic: "must be a non-pointer struct",
+	}, {
+		label:     "FilterField",
+		fnc:       FilterField,
+		args:      []interface{}{&ts.StructA{}, "X", Ignore()},
+		wantPanic: "must be a non-pointer struct",
+	}, {
+		label:     "FilterField",
+		fnc:       FilterField,
+		args:      []interface{}{ts.StructA{}, "Y", Ignore()},
+		wantPanic: "has no field",
+	}, {
+		label:     "FilterField",
+		fnc:       FilterField,
+		args:      []interface{}{ts.StructA{}, "X", Reporter(&defaultReporter{})},
+		wantPanic: "invalid option type",
+	}, {
+		label: "Not",
+		fnc:   Not,
+		args:  []interface{}{FilterPath(func(Path) bool { return true }, Ignore())},
+	}, {
+		label:     "Not",
+		fnc:       Not,
+		args:      []interface{}{Ignore()},
+		wantPanic: "invalid option type",
 	}}
 
 	for _, tt := range tests {
@@ -214,3 +339,89 @@ func TestOptionPanic(t *testing.T) {
 		})
 	}
 }
+
+// Test that the default transformer name is stable across calls,
+// and that an invalid name is rejected every time it is used.
+func TestTransformerName(t *testing.T) {
+	f := func(s string) []byte { return []byte(s) }
+	want := Transformer("", f).(*transformer).name
+	if want == "" {
+		t.Fatalf("Transformer name is empty")
+	}
+	for i := 0; i < 2; i++ {
+		if got := Transformer("", f).(*transformer).name; got != want {
+			t.Errorf("call %d: Transformer name = %q, want %q", i, got, want)
+		}
+		func() {
+			defer func() {
+				if ex := recover(); ex == nil || !strings.Contains(fmt.Sprint(ex), "invalid name") {
+					t.Errorf("call %d: panic = %v, want invalid name", i, ex)
+				}
+			}()
+			Transformer("not valid", f)
+		}()
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	eqInts := Comparer(func(x, y int) bool { return x == y })
+	tests := []struct {
+		label   string  // Test description
+		opts    Options // Options to validate
+		wantErr string  // Expected error message
+	}{{
+		label: "Empty",
+	}, {
+		label: "Filtered",
+		opts: Options{
+			FilterPath(func(Path) bool { return true }, Ignore()),
+			eqInts,
+			Transformer("", func(string) int { return 0 }),
+			AllowUnexported(ts.StructA{}),
+			Reporter(&defaultReporter{}),
+			nil,
+		},
+	}, {
+		label: "FilteredConflict",
+		opts: Options{
+			eqInts,
+			FilterPath(func(Path) bool { return true }, Comparer(func(x, y int) bool { return true })),
+		},
+	}, {
+		label:   "UnfilteredIgnore",
+		opts:    Options{Ignore()},
+		wantErr: "cannot use an unfiltered option: Ignore()",
+	}, {
+		label:   "UnfilteredComparer",
+		opts:    Options{Options{Comparer(func(x, y interface{}) bool { return true })}},
+		wantErr: "cannot use an unfiltered option",
+	}, {
+		label:   "UnfilteredTransformer",
+		opts:    Options{Transformer("", func(interface{}) int { return 0 })},
+		wantErr: "cannot use an unfiltered option",
+	}, {
+		label:   "AmbiguousComparers",
+		opts:    Options{eqInts, Comparer(func(x, y int) bool { return true })},
+		wantErr: "ambiguous set of applicable options for type int",
+	}, {
+		label:   "AmbiguousComparerAndTransformer",
+		opts:    Options{eqInts, Options{Transformer("", func(int) string { return "" })}},
+		wantErr: "ambiguous set of applicable options for type int",
+	}, {
+		label:   "Multiple",
+		opts:    Options{Ignore(), eqInts, eqInts},
+		wantErr: "cannot use an unfiltered option: Ignore()\nambiguous set of applicable options for type int",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			err := tt.opts.Validate()
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("Validate() = %v, want nil", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
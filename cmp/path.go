@@ -75,6 +75,40 @@ func (pa *Path) pop() {
 	*pa = (*pa)[:len(*pa)-1]
 }
 
+// clone returns a deep copy of the Path such that the copied steps remain
+// valid even after the original steps are popped and reused.
+func (pa Path) clone() Path {
+	out := make(Path, len(pa))
+	for i, ps := range pa {
+		switch ps := ps.(type) {
+		case *pathStep:
+			ps2 := *ps
+			out[i] = &ps2
+		case StructField:
+			ps2 := *ps.structField
+			out[i] = StructField{&ps2}
+		case SliceIndex:
+			ps2 := *ps.sliceIndex
+			out[i] = SliceIndex{&ps2}
+		case MapIndex:
+			ps2 := *ps.mapIndex
+			out[i] = MapIndex{&ps2}
+		case Indirect:
+			ps2 := *ps.indirect
+			out[i] = Indirect{&ps2}
+		case TypeAssertion:
+			ps2 := *ps.typeAssertion
+			out[i] = TypeAssertion{&ps2}
+		case Transform:
+			ps2 := *ps.transform
+			out[i] = Transform{&ps2}
+		default:
+			out[i] = ps
+		}
+	}
+	return out
+}
+
 // Last returns the last [PathStep] in the Path.
 // If the path is empty, this returns a non-nil [PathStep]
 // that reports a nil [PathStep.Type].
@@ -97,6 +131,191 @@ func (pa Path) Index(i int) PathStep {
 	return pa[i]
 }
 
+// LastStructField returns the last [PathStep] in the Path
+// and reports whether it is a [StructField].
+func (pa Path) LastStructField() (StructField, bool) {
+	ps, ok := pa.Last().(StructField)
+	return ps, ok
+}
+
+// LastSliceIndex returns the last [PathStep] in the Path
+// and reports whether it is a [SliceIndex].
+func (pa Path) LastSliceIndex() (SliceIndex, bool) {
+	ps, ok := pa.Last().(SliceIndex)
+	return ps, ok
+}
+
+// LastMapIndex returns the last [PathStep] in the Path
+// and reports whether it is a [MapIndex].
+func (pa Path) LastMapIndex() (MapIndex, bool) {
+	ps, ok := pa.Last().(MapIndex)
+	return ps, ok
+}
+
+// LastIndirect returns the last [PathStep] in the Path
+// and reports whether it is an [Indirect].
+func (pa Path) LastIndirect() (Indirect, bool) {
+	ps, ok := pa.Last().(Indirect)
+	return ps, ok
+}
+
+// LastTypeAssertion returns the last [PathStep] in the Path
+// and reports whether it is a [TypeAssertion].
+func (pa Path) LastTypeAssertion() (TypeAssertion, bool) {
+	ps, ok := pa.Last().(TypeAssertion)
+	return ps, ok
+}
+
+// IsPrefix reports whether pa is a prefix of other, such that the node
+// identified by other is pa itself or a descendant of it.
+// Steps are matched structurally, rather than by the values they hold:
+// [StructField] steps match by name, [SliceIndex] steps by index,
+// [MapIndex] steps by key using [reflect.DeepEqual], [Transform] steps by
+// the originating [Transformer], and all steps must have the same type.
+func (pa Path) IsPrefix(other Path) bool {
+	if len(pa) > len(other) {
+		return false
+	}
+	for i := range pa {
+		if !equalPathStep(pa[i], other[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether pa and other identify the same node.
+// Steps are matched structurally in the same way as [Path.IsPrefix].
+func (pa Path) Equal(other Path) bool {
+	return len(pa) == len(other) && pa.IsPrefix(other)
+}
+
+// equalPathStep reports whether two steps are structurally equal.
+func equalPathStep(x, y PathStep) bool {
+	if x.Type() != y.Type() {
+		return false
+	}
+	switch x := x.(type) {
+	case StructField:
+		y, ok := y.(StructField)
+		return ok && x.Name() == y.Name()
+	case SliceIndex:
+		y, ok := y.(SliceIndex)
+		if !ok {
+			return false
+		}
+		ix1, iy1 := x.SplitKeys()
+		ix2, iy2 := y.SplitKeys()
+		return ix1 == ix2 && iy1 == iy2
+	case MapIndex:
+		y, ok := y.(MapIndex)
+		if !ok {
+			return false
+		}
+		kx, ky := x.Key(), y.Key()
+		if !kx.CanInterface() || !ky.CanInterface() {
+			return x.String() == y.String()
+		}
+		return reflect.DeepEqual(kx.Interface(), ky.Interface())
+	case Indirect:
+		_, ok := y.(Indirect)
+		return ok
+	case TypeAssertion:
+		_, ok := y.(TypeAssertion)
+		return ok
+	case Transform:
+		y, ok := y.(Transform)
+		return ok && x.Option() == y.Option()
+	default:
+		_, ok := y.(*pathStep)
+		return ok
+	}
+}
+
+// LookupValue retrieves the value at the node identified by pa within root,
+// where root is either the x or y value passed to [Equal] or [Diff]
+// that produced the path. Struct fields, slice and array elements,
+// map entries, pointers, and interfaces are traversed, and any [Transform]
+// step is evaluated by calling the transformer function again.
+//
+// It reports an error if the path does not apply to root, such as when
+// a map entry does not exist, a pointer or interface is nil,
+// or a [SliceIndex] step refers to different indexes in x and y.
+// The returned value may not be interfaceable if the path traverses
+// an unexported struct field.
+func (pa Path) LookupValue(root interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(root)
+	for i, ps := range pa {
+		var err error
+		if v, err = lookupPathStep(v, ps); err != nil {
+			return reflect.Value{}, fmt.Errorf("%#v: %v", pa[:i+1], err)
+		}
+	}
+	return v, nil
+}
+
+// lookupPathStep retrieves the value that ps refers to within v,
+// where v is the value of the parent node.
+func lookupPathStep(v reflect.Value, ps PathStep) (reflect.Value, error) {
+	t := ps.Type()
+	switch ps := ps.(type) {
+	case StructField:
+		v = v.Field(ps.Index())
+	case SliceIndex:
+		switch k := ps.Key(); {
+		case k < 0:
+			return v, fmt.Errorf("index is ambiguous: %v", ps)
+		case k >= v.Len():
+			return v, fmt.Errorf("index out of range: %d", k)
+		default:
+			v = v.Index(k)
+		}
+	case MapIndex:
+		if v = v.MapIndex(ps.Key()); !v.IsValid() {
+			return v, fmt.Errorf("map entry does not exist")
+		}
+	case Indirect:
+		if v.IsNil() {
+			return v, fmt.Errorf("nil pointer")
+		}
+		v = v.Elem()
+	case TypeAssertion:
+		if v.IsNil() {
+			return v, fmt.Errorf("nil interface")
+		}
+		v = v.Elem()
+	case Transform:
+		if !v.CanInterface() {
+			return v, fmt.Errorf("cannot transform unexported value")
+		}
+		out := ps.Func().Call([]reflect.Value{v})
+		if len(out) == 2 && !out[1].IsNil() {
+			return v, fmt.Errorf("transformer %s returned an error: %v", ps.Name(), out[1].Interface())
+		}
+		v = out[0]
+	default:
+		// The root value of mismatching types is wrapped in an interface.
+		if t != nil && t.Kind() == reflect.Interface {
+			vv := reflect.New(t).Elem()
+			if v.IsValid() {
+				if !v.Type().AssignableTo(t) {
+					return v, fmt.Errorf("got type %v, want %v", v.Type(), t)
+				}
+				vv.Set(v)
+			}
+			v = vv
+		}
+	}
+	if !v.IsValid() || v.Type() != t {
+		var vt reflect.Type
+		if v.IsValid() {
+			vt = v.Type()
+		}
+		return v, fmt.Errorf("got type %v, want %v", vt, t)
+	}
+	return v, nil
+}
+
 // String returns the simplified path to a node.
 // The simplified path only contains struct field accesses.
 //
@@ -119,6 +338,25 @@ func (pa Path) String() string {
 //
 //	(*root.MyMap["key"].(*mypkg.MyStruct).MySlices)[2][3].MyField
 func (pa Path) GoString() string {
+	return pa.goString(false)
+}
+
+// GoSyntax returns the path to a specific node as a Go expression
+// that may be pasted into Go source code, where the root value is named x.
+// Unlike [Path.GoString], the root type is omitted and slice indexes
+// are always a single index, which is into y only if the element
+// does not exist in x.
+// Map keys are formatted as Go literals using the %#v verb,
+// while transformations are formatted as calls to a function of the same name.
+//
+// For example:
+//
+//	(*x.MyMap["key"].(*mypkg.MyStruct).MySlices)[2][3].MyField
+func (pa Path) GoSyntax() string {
+	return pa.goString(true)
+}
+
+func (pa Path) goString(asExpr bool) string {
 	var ssPre, ssPost []string
 	var numIndirect int
 	for i, s := range pa {
@@ -148,6 +386,20 @@ func (pa Path) GoString() string {
 			ssPre = append(ssPre, s.trans.name+"(")
 			ssPost = append(ssPost, ")")
 			continue
+		case *pathStep:
+			if asExpr {
+				ssPost = append(ssPost, "x")
+				continue
+			}
+		case SliceIndex:
+			if asExpr {
+				i, iy := s.SplitKeys()
+				if i < 0 {
+					i = iy // Element only exists in y
+				}
+				ssPost = append(ssPost, fmt.Sprintf("[%d]", i))
+				continue
+			}
 		}
 		ssPost = append(ssPost, s.String())
 	}
@@ -157,6 +409,129 @@ func (pa Path) GoString() string {
 	return strings.Join(ssPre, "") + strings.Join(ssPost, "")
 }
 
+// MatchesGlob reports whether the path matches the pattern,
+// which describes the steps after the root step using a syntax
+// similar to [Path.GoString]:
+//   - "Name" or ".Name" matches a struct field called Name.
+//   - "[3]" matches a slice or array index of 3.
+//   - `["key"]` matches a map index with a key formatted by %#v as "key".
+//   - "Name()" matches a transformation by a [Transformer] called Name.
+//   - "*" or ".*" matches any single step.
+//   - "[*]" matches any slice, array, or map index.
+//   - "**" or ".**" matches any number of steps, including none.
+//
+// Pointer indirections and type assertions in the path are skipped
+// and the pattern must match the remainder of the path in its entirety.
+// For example, "Foo.Bar[*].Baz" and "Foo.Bar.*.Baz" both match the Baz field
+// of any element within Foo.Bar, while "**.ID" matches a field called ID
+// at any depth. It panics if the pattern is malformed.
+func (pa Path) MatchesGlob(pattern string) bool {
+	return matchPathPattern(mustParsePathPattern(pattern), pa.globSteps())
+}
+
+// globSteps returns the steps matched by a path pattern, which excludes
+// the root step, pointer indirections, and type assertions.
+func (pa Path) globSteps() []PathStep {
+	var steps []PathStep
+	for i, ps := range pa {
+		switch ps.(type) {
+		case Indirect, TypeAssertion:
+		default:
+			if i > 0 {
+				steps = append(steps, ps)
+			}
+		}
+	}
+	return steps
+}
+
+func mustParsePathPattern(pattern string) []string {
+	toks, err := parsePathPattern(pattern)
+	if err != nil {
+		panic(fmt.Sprintf("invalid path pattern %q: %v", pattern, err))
+	}
+	return toks
+}
+
+// parsePathPattern splits a pattern into tokens that are formatted
+// identically to the String method of the PathStep that they match.
+func parsePathPattern(pattern string) (toks []string, err error) {
+	for s := pattern; len(s) > 0; {
+		switch {
+		case s[0] == '[':
+			n, inQuote := -1, false
+			for i := 1; i < len(s) && n < 0; i++ {
+				switch {
+				case inQuote && s[i] == '\\':
+					i++ // Skip escaped character
+				case s[i] == '"':
+					inQuote = !inQuote
+				case !inQuote && s[i] == ']':
+					n = i + 1
+				}
+			}
+			if n < 0 {
+				return nil, fmt.Errorf("missing closing bracket")
+			}
+			if n == 2 {
+				return nil, fmt.Errorf("empty index")
+			}
+			toks, s = append(toks, s[:n]), s[n:]
+		default:
+			s = strings.TrimPrefix(s, ".")
+			n := strings.IndexAny(s, ".[")
+			if n < 0 {
+				n = len(s)
+			}
+			name := s[:n]
+			if name == "" {
+				return nil, fmt.Errorf("empty field name")
+			}
+			if name == "*" || name == "**" {
+				toks = append(toks, name) // Any single step or any number of steps
+			} else if strings.HasSuffix(name, "()") {
+				toks = append(toks, name) // Transform
+			} else {
+				toks = append(toks, "."+name) // StructField
+			}
+			s = s[n:]
+		}
+	}
+	return toks, nil
+}
+
+// matchPathPattern reports whether the tokens match the steps.
+func matchPathPattern(toks []string, steps []PathStep) bool {
+	for ; len(toks) > 0; toks, steps = toks[1:], steps[1:] {
+		if toks[0] == "**" {
+			for i := 0; i <= len(steps); i++ {
+				if matchPathPattern(toks[1:], steps[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(steps) == 0 || !matchPathStep(toks[0], steps[0]) {
+			return false
+		}
+	}
+	return len(steps) == 0
+}
+
+// matchPathStep reports whether a single token matches the step.
+func matchPathStep(tok string, step PathStep) bool {
+	if tok == "*" {
+		return true // Any kind of step
+	}
+	switch step.(type) {
+	case SliceIndex, MapIndex:
+		if tok == "[*]" {
+			return true
+		}
+	}
+	return tok == step.String()
+}
+
 type pathStep struct {
 	typ    reflect.Type
 	vx, vy reflect.Value
@@ -182,6 +557,7 @@ type structField struct {
 	pathStep
 	name string
 	idx  int
+	ptyp reflect.Type // Parent struct type
 
 	// These fields are used for forcibly accessing an unexported field.
 	// pvx, pvy, and field are only valid if unexported is true.
@@ -215,6 +591,11 @@ func (sf StructField) Name() string { return sf.name }
 // See [reflect.Type.Field].
 func (sf StructField) Index() int { return sf.idx }
 
+// Field is the field information in the parent struct type,
+// which provides access to the field's tag, package path, and whether it
+// is an embedded field. See [reflect.Type.Field].
+func (sf StructField) Field() reflect.StructField { return sf.ptyp.Field(sf.idx) }
+
 // SliceIndex is a [PathStep] that represents an index operation on
 // a slice or array at some index [SliceIndex.Key].
 type SliceIndex struct{ *sliceIndex }
@@ -0,0 +1,325 @@
+// Copyright 2026, The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmp_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+type pathTestStruct struct {
+	Foo struct{ Bar []*pathTestElem }
+	M   map[string]int
+	I   interface{}
+	S   string
+}
+
+type pathTestElem struct{ Baz, Qux int }
+
+// mustDiffPath returns the path to the only difference between x and y.
+func mustDiffPath(t *testing.T, x, y interface{}, opts ...cmp.Option) cmp.Path {
+	t.Helper()
+	r := cmp.DiffStructured(x, y, opts...)
+	if len(r.Diffs) != 1 {
+		t.Fatalf("got %d differences, want 1", len(r.Diffs))
+	}
+	return r.Diffs[0].Path
+}
+
+func TestPathMatchesGlob(t *testing.T) {
+	base := func() pathTestStruct {
+		var v pathTestStruct
+		v.Foo.Bar = []*pathTestElem{{1, 2}, {3, 4}}
+		v.M = map[string]int{"a": 1, "b]": 2}
+		v.I = pathTestElem{5, 6}
+		v.S = "a\nb\nc"
+		return v
+	}
+	x := base()
+	yBaz, yMap, yIface, yStr := base(), base(), base(), base()
+	yBaz.Foo.Bar = []*pathTestElem{{1, 2}, {0, 4}}
+	yMap.M = map[string]int{"a": 1, "b]": 0}
+	yIface.I = pathTestElem{5, 0}
+	yStr.S = "a\nb\nd"
+	split := cmpopts.AcyclicTransformer("Split", func(s string) []string { return strings.Split(s, "\n") })
+
+	pathBaz := mustDiffPath(t, x, yBaz)
+	pathMap := mustDiffPath(t, x, yMap)
+	pathIface := mustDiffPath(t, x, yIface)
+	pathStr := mustDiffPath(t, x, yStr, split)
+
+	tests := []struct {
+		path    cmp.Path
+		pattern string
+		want    bool
+	}{
+		{pathBaz, "Foo.Bar[1].Baz", true},
+		{pathBaz, ".Foo.Bar[1].Baz", true},
+		{pathBaz, "Foo.Bar[*].Baz", true},
+		{pathBaz, "Foo.*[*].*", true},
+		{pathBaz, "Foo.Bar[0].Baz", false},
+		{pathBaz, "Foo.Bar[*].Qux", false},
+		{pathBaz, "Foo.Bar[*]", false},
+		{pathBaz, "Bar[*].Baz", false},
+		{pathBaz, "Foo.Bar.*.Baz", true},
+		{pathBaz, "*.*.*.Baz", true},
+		{pathBaz, "Foo.Bar.*", false},
+		{pathMap, `M["b]"]`, true},
+		{pathMap, `M[*]`, true},
+		{pathMap, `M["a"]`, false},
+		{pathMap, "M.*", true},
+		{pathMap, "*.*", true},
+		{pathMap, "*", false},
+		{pathIface, "I.Qux", true},
+		{pathIface, "I.*", true},
+		{pathStr, "S.Split()[2]", true},
+		{pathStr, "S.Split()[*]", true},
+		{pathStr, "S[*]", false},
+		{pathStr, "S.*[2]", true},
+		{pathBaz, "**.Baz", true},
+		{pathBaz, "**", true},
+		{pathBaz, "Foo.**.Baz", true},
+		{pathBaz, "Foo.Bar[1].Baz.**", true},
+		{pathBaz, "**.Bar.**", true},
+		{pathBaz, "**[*].Baz", true},
+		{pathBaz, "**.Qux", false},
+		{pathBaz, "Foo.**.Bar", false},
+		{pathStr, "**[2]", true},
+	}
+	for _, tt := range tests {
+		if got := tt.path.MatchesGlob(tt.pattern); got != tt.want {
+			t.Errorf("%#v.MatchesGlob(%q) = %v, want %v", tt.path, tt.pattern, got, tt.want)
+		}
+	}
+
+	for _, pattern := range []string{"Foo..Bar", "Foo[", "Foo[]", `M["]`, "Foo."} {
+		func() {
+			defer func() {
+				if ex := recover(); ex == nil {
+					t.Errorf("MatchesGlob(%q) did not panic", pattern)
+				}
+			}()
+			pathBaz.MatchesGlob(pattern)
+		}()
+	}
+}
+
+func TestPathGoSyntax(t *testing.T) {
+	type S struct {
+		M map[string]*pathTestElem
+		I interface{}
+		L []int
+		S string
+	}
+	base := func() S {
+		return S{
+			M: map[string]*pathTestElem{"a\n": {1, 2}},
+			I: pathTestElem{3, 4},
+			L: []int{1, 2, 3},
+			S: "a\nb",
+		}
+	}
+	x := base()
+	yMap, yIface, ySlice, yStr := base(), base(), base(), base()
+	yMap.M["a\n"].Qux = 0
+	yIface.I = pathTestElem{3, 0}
+	ySlice.L = []int{1, 2, 3, 4}
+	yStr.S = "a\nc"
+	split := cmpopts.AcyclicTransformer("Split", func(s string) []string { return strings.Split(s, "\n") })
+
+	tests := []struct {
+		path   cmp.Path
+		want   string
+		wantGo string
+	}{{
+		path:   mustDiffPath(t, x, yMap),
+		want:   `x.M["a\n"].Qux`,
+		wantGo: `{cmp_test.S}.M["a\n"].Qux`,
+	}, {
+		path:   mustDiffPath(t, x, yIface),
+		want:   `x.I.(cmp_test.pathTestElem).Qux`,
+		wantGo: `{cmp_test.S}.I.(cmp_test.pathTestElem).Qux`,
+	}, {
+		path:   mustDiffPath(t, x, ySlice),
+		want:   `x.L[3]`,
+		wantGo: `{cmp_test.S}.L[?->3]`,
+	}, {
+		path:   mustDiffPath(t, x, yStr, split),
+		want:   `Split(x.S)[1]`,
+		wantGo: `Split({cmp_test.S}.S)[1]`,
+	}}
+	for _, tt := range tests {
+		if got := tt.path.GoSyntax(); got != tt.want {
+			t.Errorf("GoSyntax() = %s, want %s", got, tt.want)
+		}
+		if got := tt.path.GoString(); got != tt.wantGo {
+			t.Errorf("GoString() = %s, want %s", got, tt.wantGo)
+		}
+	}
+}
+
+func TestStructFieldField(t *testing.T) {
+	type S struct {
+		A int
+		b int `db:"b,omitempty"`
+	}
+	p := mustDiffPath(t, S{1, 2}, S{1, 3}, cmp.AllowUnexported(S{}))
+	sf, ok := p.Last().(cmp.StructField)
+	if !ok {
+		t.Fatalf("last step is %T, want cmp.StructField", p.Last())
+	}
+	f := sf.Field()
+	if f.Name != "b" || f.Index[0] != sf.Index() || f.Tag.Get("db") != "b,omitempty" || f.PkgPath == "" {
+		t.Errorf("Field() = %+v, want field b of S", f)
+	}
+}
+
+func TestPathIsPrefix(t *testing.T) {
+	x := pathTestStruct{M: map[string]int{"a": 1}}
+	x.Foo.Bar = []*pathTestElem{{1, 2}, {3, 4}}
+	yBaz, yQux, yMap := x, x, x
+	yBaz.Foo.Bar = []*pathTestElem{{1, 2}, {0, 4}}
+	yQux.Foo.Bar = []*pathTestElem{{1, 0}, {3, 4}}
+	yMap.M = map[string]int{"a": 0}
+
+	pathBaz := mustDiffPath(t, x, yBaz)
+	pathQux := mustDiffPath(t, x, yQux)
+	pathMap := mustDiffPath(t, x, yMap)
+	pathMap2 := mustDiffPath(t, yMap, x)
+
+	tests := []struct {
+		x, y cmp.Path
+		want bool
+	}{
+		{pathBaz, pathBaz, true},
+		{pathBaz[:3], pathBaz, true},
+		{pathBaz[:0], pathBaz, true},
+		{pathBaz, pathBaz[:3], false},
+		{pathQux[:4], pathBaz, false},
+		{pathQux[:3], pathBaz, true},
+		{pathMap, pathMap2, true},
+		{pathMap, pathBaz, false},
+	}
+	for _, tt := range tests {
+		if got := tt.x.IsPrefix(tt.y); got != tt.want {
+			t.Errorf("%#v.IsPrefix(%#v) = %v, want %v", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestPathEqual(t *testing.T) {
+	x := pathTestStruct{M: map[string]int{"a": 1}}
+	x.Foo.Bar = []*pathTestElem{{1, 2}, {3, 4}}
+	yBaz, yMap := x, x
+	yBaz.Foo.Bar = []*pathTestElem{{1, 2}, {0, 4}}
+	yMap.M = map[string]int{"a": 0}
+
+	pathBaz := mustDiffPath(t, x, yBaz)
+	pathMap := mustDiffPath(t, x, yMap)
+	pathMap2 := mustDiffPath(t, yMap, x)
+
+	tests := []struct {
+		x, y cmp.Path
+		want bool
+	}{
+		{pathBaz, pathBaz, true},
+		{pathBaz[:0], pathBaz[:0], true},
+		{pathBaz[:3], pathBaz, false},
+		{pathBaz, pathBaz[:3], false},
+		{pathMap, pathMap2, true},
+		{pathMap, pathBaz, false},
+	}
+	for _, tt := range tests {
+		if got := tt.x.Equal(tt.y); got != tt.want {
+			t.Errorf("%#v.Equal(%#v) = %v, want %v", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestPathLookupValue(t *testing.T) {
+	x := pathTestStruct{M: map[string]int{"a": 1}, I: pathTestElem{5, 6}, S: "a\nb"}
+	x.Foo.Bar = []*pathTestElem{{1, 2}, {3, 4}}
+	yBaz, yMap, yIface, yStr, yLen := x, x, x, x, x
+	yBaz.Foo.Bar = []*pathTestElem{{1, 2}, {0, 4}}
+	yMap.M = map[string]int{"a": 0}
+	yIface.I = pathTestElem{5, 0}
+	yStr.S = "a\nc"
+	yLen.Foo.Bar = []*pathTestElem{{1, 2}, {3, 4}, {5, 6}}
+	split := cmpopts.AcyclicTransformer("Split", func(s string) []string { return strings.Split(s, "\n") })
+
+	tests := []struct {
+		path    cmp.Path
+		root    interface{}
+		want    interface{}
+		wantErr string
+	}{
+		{path: mustDiffPath(t, x, yBaz), root: x, want: 3},
+		{path: mustDiffPath(t, x, yBaz), root: yBaz, want: 0},
+		{path: mustDiffPath(t, x, yMap), root: yMap, want: 0},
+		{path: mustDiffPath(t, x, yIface), root: x, want: 6},
+		{path: mustDiffPath(t, x, yStr, split), root: yStr, want: "c"},
+		{path: mustDiffPath(t, x, yBaz)[:2], root: x, want: x.Foo},
+		{path: mustDiffPath(t, x, yMap), root: pathTestStruct{}, wantErr: "map entry does not exist"},
+		{path: mustDiffPath(t, x, yIface), root: pathTestStruct{}, wantErr: "nil interface"},
+		{path: mustDiffPath(t, x, yLen), root: x, wantErr: "index is ambiguous"},
+		{path: mustDiffPath(t, x, yBaz), root: "x", wantErr: "got type string"},
+		{path: mustDiffPath(t, 1, "1"), root: 1, want: 1},
+	}
+	for _, tt := range tests {
+		got, err := tt.path.LookupValue(tt.root)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%#v.LookupValue(%v) error = %v, want %q", tt.path, tt.root, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%#v.LookupValue(%v) error = %v", tt.path, tt.root, err)
+			continue
+		}
+		if !cmp.Equal(got.Interface(), tt.want) {
+			t.Errorf("%#v.LookupValue(%v) = %v, want %v", tt.path, tt.root, got, tt.want)
+		}
+	}
+}
+
+func TestPathLastStep(t *testing.T) {
+	x := pathTestStruct{M: map[string]int{"a": 1}, I: pathTestElem{5, 6}}
+	x.Foo.Bar = []*pathTestElem{{1, 2}}
+	yBaz, yMap, yIface, yPtr := x, x, x, x
+	yBaz.Foo.Bar = []*pathTestElem{{0, 2}}
+	yMap.M = map[string]int{"a": 0}
+	yIface.I = pathTestElem{5, 0}
+	yPtr.Foo.Bar = []*pathTestElem{nil}
+
+	pathBaz := mustDiffPath(t, x, yBaz)
+	if sf, ok := pathBaz.LastStructField(); !ok || sf.Name() != "Baz" {
+		t.Errorf("%#v.LastStructField() = (%v, %v), want (.Baz, true)", pathBaz, sf, ok)
+	}
+	if _, ok := pathBaz.LastMapIndex(); ok {
+		t.Errorf("%#v.LastMapIndex() reports true, want false", pathBaz)
+	}
+	if _, ok := pathBaz[:len(pathBaz)-1].LastIndirect(); !ok {
+		t.Errorf("%#v.LastIndirect() reports false, want true", pathBaz[:len(pathBaz)-1])
+	}
+	pathPtr := mustDiffPath(t, x, yPtr)
+	if si, ok := pathPtr.LastSliceIndex(); !ok || si.Key() != 0 {
+		t.Errorf("%#v.LastSliceIndex() = (%v, %v), want ([0], true)", pathPtr, si, ok)
+	}
+	pathMap := mustDiffPath(t, x, yMap)
+	if mi, ok := pathMap.LastMapIndex(); !ok || mi.Key().String() != "a" {
+		t.Errorf("%#v.LastMapIndex() = (%v, %v), want ([\"a\"], true)", pathMap, mi, ok)
+	}
+	pathIface := mustDiffPath(t, x, yIface)
+	if _, ok := pathIface[:len(pathIface)-1].LastTypeAssertion(); !ok {
+		t.Errorf("%#v.LastTypeAssertion() reports false, want true", pathIface[:len(pathIface)-1])
+	}
+	if _, ok := (cmp.Path{}).LastStructField(); ok {
+		t.Errorf("Path{}.LastStructField() reports true, want false")
+	}
+}
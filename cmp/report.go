@@ -4,6 +4,11 @@
 
 package cmp
 
+import (
+	"fmt"
+	"strings"
+)
+
 // defaultReporter implements the reporter interface.
 //
 // As Equal serially calls the PushStep, Report, and PopStep methods, the
@@ -18,6 +23,44 @@ package cmp
 type defaultReporter struct {
 	root *valueNode
 	curr *valueNode
+	opts reportOptions
+}
+
+// reportOptions configures the report produced by the defaultReporter.
+type reportOptions struct {
+	// Summary specifies whether to append a line with the number of
+	// insertions, deletions, and modifications.
+	Summary bool
+
+	// MaxDiffs is the maximum number of differences to report,
+	// counted as nodes of the report tree rather than formatted lines.
+	// If zero, then all differences are reported.
+	MaxDiffs int
+
+	// Color specifies whether to wrap removed and inserted lines in
+	// ANSI escape codes for red and green text, respectively.
+	Color bool
+
+	// NumContextRecords is the number of surrounding equal records to print.
+	// If negative, then all equal records are printed.
+	NumContextRecords int
+
+	// StringDiffAlgorithm is the algorithm for differencing lines of text.
+	StringDiffAlgorithm StringDiffAlgorithm
+
+	// SideBySide specifies whether to format each modified leaf value
+	// as a single record with both the removed and inserted values.
+	SideBySide bool
+
+	// UnifiedDiff specifies whether to format differences in multi-line text
+	// as a unified diff.
+	UnifiedDiff bool
+
+	// Verbosity is the verbosity level of the report between 0 and 3.
+	Verbosity int
+
+	// ElideTypes specifies whether to omit all type names.
+	ElideTypes bool
 }
 
 func (r *defaultReporter) PushStep(ps PathStep) {
@@ -41,10 +84,153 @@ func (r *defaultReporter) String() string {
 	if r.root.NumDiff == 0 {
 		return ""
 	}
+	var summary string
+	if r.opts.Summary {
+		ins, del, mod := r.root.diffSummary()
+		summary = fmt.Sprintf("// %s, %s, %s\n",
+			pluralize(ins, "insertion"), pluralize(del, "deletion"), pluralize(mod, "modification"))
+	}
+	var omitted int
+	if r.opts.MaxDiffs > 0 {
+		remaining := r.opts.MaxDiffs
+		omitted = r.root.limitDiffs(&remaining)
+	}
+
 	ptrs := new(pointerReferences)
-	text := formatOptions{}.FormatDiff(r.root, ptrs)
+	opts := formatOptions{
+		NumContextRecords:   r.opts.NumContextRecords,
+		StringDiffAlgorithm: r.opts.StringDiffAlgorithm,
+		SideBySide:          r.opts.SideBySide,
+		UnifiedDiff:         r.opts.UnifiedDiff,
+		ElideTypes:          r.opts.ElideTypes,
+	}.withReportVerbosity(r.opts.Verbosity)
+	text := opts.FormatDiff(r.root, ptrs)
 	resolveReferences(text)
-	return text.String()
+	out := text.String()
+	if r.opts.Color {
+		out = colorizeLines(out)
+	}
+	if omitted > 0 {
+		out += fmt.Sprintf("... %s ...\n", pluralize(omitted, "more difference"))
+	}
+	return out + summary
+}
+
+// limitDiffs removes records from the tree rooted at r such that at most
+// remaining differences are kept, where remaining is decremented by the
+// number of differences kept. It reports the number of differences removed.
+// Equal records are always kept.
+func (r *valueNode) limitDiffs(remaining *int) (omitted int) {
+	switch {
+	case r.NumDiff <= *remaining:
+		*remaining -= r.NumDiff
+	case formatOptions{}.CanFormatDiffSlice(r):
+		*remaining = 0 // Specialized formatting always prints every element
+	case r.Value != nil:
+		omitted = r.Value.limitDiffs(remaining)
+	case len(r.Records) > 0:
+		recs := r.Records[:0]
+		for _, rec := range r.Records {
+			if rec.Value.NumDiff > 0 && *remaining == 0 {
+				omitted += rec.Value.NumDiff
+				continue
+			}
+			omitted += rec.Value.limitDiffs(remaining)
+			recs = append(recs, rec)
+		}
+		r.Records = recs
+	}
+	r.NumDiff -= omitted
+	return omitted
+}
+
+// diffSummary counts the number of inserted, deleted, and modified nodes
+// within the tree rooted at r.
+func (r *valueNode) diffSummary() (ins, del, mod int) {
+	switch {
+	case r.NumDiff == 0:
+	case !r.ValueX.IsValid():
+		ins++
+	case !r.ValueY.IsValid():
+		del++
+	case r.Value != nil:
+		return r.Value.diffSummary()
+	case len(r.Records) > 0:
+		for _, rec := range r.Records {
+			i, d, m := rec.Value.diffSummary()
+			ins, del, mod = ins+i, del+d, mod+m
+		}
+	default:
+		mod++
+	}
+	return ins, del, mod
+}
+
+const (
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiReset = "\x1b[0m"
+)
+
+// colorizeLines wraps every line in s that starts with a removed or inserted
+// diff marker with the ANSI escape codes for red or green text.
+func colorizeLines(s string) string {
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(s, "\n") {
+		text := strings.TrimSuffix(line, "\n")
+		switch {
+		case strings.HasPrefix(text, string(diffRemoved)):
+			b.WriteString(ansiRed + text + ansiReset + line[len(text):])
+		case strings.HasPrefix(text, string(diffInserted)):
+			b.WriteString(ansiGreen + text + ansiReset + line[len(text):])
+		default:
+			b.WriteString(line)
+		}
+	}
+	return b.String()
+}
+
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// structuredReporter implements the reporter interface by recording every
+// unequal leaf node as a DiffEntry (see DiffStructured).
+type structuredReporter struct {
+	curPath Path
+	diffs   []DiffEntry
+}
+
+func (r *structuredReporter) PushStep(ps PathStep) {
+	r.curPath.push(ps)
+}
+func (r *structuredReporter) Report(rs Result) {
+	if !rs.Equal() {
+		vx, vy := r.curPath.Last().Values()
+		r.diffs = append(r.diffs, DiffEntry{Path: r.curPath.clone(), X: vx, Y: vy})
+	}
+}
+func (r *structuredReporter) PopStep() {
+	r.curPath.pop()
+}
+
+// firstDiffReporter is a defaultReporter that halts the comparison by
+// panicking with firstDiffFound once the first unequal leaf node is reported
+// (see EqualReason). The deferred calls to PopStep complete the report tree
+// as the panic unwinds the comparison.
+type firstDiffReporter struct{ defaultReporter }
+
+// firstDiffFound is the panic value used by firstDiffReporter.
+type firstDiffFound struct{}
+
+func (r *firstDiffReporter) Report(rs Result) {
+	r.defaultReporter.Report(rs)
+	if !rs.Equal() {
+		panic(firstDiffFound{})
+	}
 }
 
 func assert(ok bool) {
@@ -9,9 +9,12 @@ import (
 	"reflect"
 )
 
-// numContextRecords is the number of surrounding equal records to print.
+// numContextRecords is the default number of surrounding equal records to print.
 const numContextRecords = 2
 
+// defaultVerbosity is the default verbosity level of the report.
+const defaultVerbosity = 1
+
 type diffMode byte
 
 const (
@@ -19,6 +22,7 @@ const (
 	diffIdentical diffMode = ' '
 	diffRemoved   diffMode = '-'
 	diffInserted  diffMode = '+'
+	diffModified  diffMode = '~' // Only used for side-by-side records
 )
 
 type typeMode int
@@ -49,6 +53,29 @@ type formatOptions struct {
 	// a slice or map node.
 	TypeMode typeMode
 
+	// NumContextRecords is the number of surrounding equal records to print.
+	// If negative, then all equal records are printed.
+	NumContextRecords int
+
+	// StringDiffAlgorithm is the algorithm for differencing lines of text.
+	StringDiffAlgorithm StringDiffAlgorithm
+
+	// SideBySide specifies whether to format a leaf value that is modified
+	// as a single record with both the removed and inserted values.
+	SideBySide bool
+
+	// UnifiedDiff specifies whether to format differences in multi-line text
+	// as a unified diff.
+	UnifiedDiff bool
+
+	// ElideTypes specifies whether to never print the type,
+	// regardless of TypeMode.
+	ElideTypes bool
+
+	// MinVerbosityLevel is the lowest verbosity level used to format
+	// a node that is not identical.
+	MinVerbosityLevel int
+
 	// formatValueOptions are options specific to printing reflect.Values.
 	formatValueOptions
 }
@@ -77,6 +104,16 @@ func (opts formatOptions) verbosity() uint {
 	}
 }
 
+// withReportVerbosity modifies the formatting options according to
+// a report verbosity level between 0 and 3, inclusive.
+func (opts formatOptions) withReportVerbosity(level int) formatOptions {
+	opts.MinVerbosityLevel = 1 + 2*level
+	opts.AvoidStringer = level >= 2
+	opts.PrintAddresses = level >= 3
+	opts.QualifiedNames = level >= 3
+	return opts
+}
+
 const maxVerbosityPreset = 6
 
 // verbosityPreset modifies the verbosity settings given an index
@@ -98,8 +135,8 @@ func verbosityPreset(opts formatOptions, i int) formatOptions {
 func (opts formatOptions) FormatDiff(v *valueNode, ptrs *pointerReferences) (out textNode) {
 	if opts.DiffMode == diffIdentical {
 		opts = opts.WithVerbosity(1)
-	} else if opts.verbosity() < 3 {
-		opts = opts.WithVerbosity(3)
+	} else if opts.verbosity() < uint(opts.MinVerbosityLevel) {
+		opts = opts.WithVerbosity(opts.MinVerbosityLevel)
 	}
 
 	// Check whether we have specialized formatting for this node.
@@ -174,14 +211,14 @@ func (opts formatOptions) FormatDiff(v *valueNode, ptrs *pointerReferences) (out
 	} else {
 		switch k := v.Type.Kind(); k {
 		case reflect.Struct, reflect.Array, reflect.Slice:
-			out = opts.formatDiffList(v.Records, k, ptrs)
+			out = opts.formatDiffList(v.Records, v.Type, ptrs)
 			out = opts.FormatType(v.Type, out)
 		case reflect.Map:
 			// Register map to support cycle detection.
 			ptrRefs := ptrs.PushPair(v.ValueX, v.ValueY, opts.DiffMode, false)
 			defer ptrs.Pop()
 
-			out = opts.formatDiffList(v.Records, k, ptrs)
+			out = opts.formatDiffList(v.Records, v.Type, ptrs)
 			out = wrapTrunkReferences(ptrRefs, out)
 			out = opts.FormatType(v.Type, out)
 		case reflect.Ptr:
@@ -201,15 +238,21 @@ func (opts formatOptions) FormatDiff(v *valueNode, ptrs *pointerReferences) (out
 	}
 }
 
-func (opts formatOptions) formatDiffList(recs []reportRecord, k reflect.Kind, ptrs *pointerReferences) textNode {
+func (opts formatOptions) formatDiffList(recs []reportRecord, t reflect.Type, ptrs *pointerReferences) textNode {
 	// Derive record name based on the data structure kind.
 	var name string
 	var formatKey func(reflect.Value) string
+	k := t.Kind()
 	switch k {
 	case reflect.Struct:
 		name = "field"
 		opts = opts.WithTypeMode(autoType)
 		formatKey = func(v reflect.Value) string { return v.String() }
+		if opts.QualifiedNames && t.Name() != "" {
+			// Qualify field names with the parent type to disambiguate
+			// identically named fields in deeply nested structs.
+			formatKey = func(v reflect.Value) string { return t.Name() + "." + v.String() }
+		}
 	case reflect.Slice, reflect.Array:
 		name = "element"
 		opts = opts.WithTypeMode(elideType)
@@ -294,19 +337,23 @@ func (opts formatOptions) formatDiffList(recs []reportRecord, k reflect.Kind, pt
 			// Compute the number of leading and trailing records to print.
 			var numLo, numHi int
 			numEqual := ds.NumIgnored + ds.NumIdentical
-			for numLo < numContextRecords && numLo+numHi < numEqual && i != 0 {
+			maxContext := opts.NumContextRecords
+			if maxContext < 0 {
+				maxContext = numEqual
+			}
+			for numLo < maxContext && numLo+numHi < numEqual && i != 0 {
 				if r := recs[numLo].Value; r.NumIgnored > 0 && r.NumSame+r.NumDiff == 0 {
 					break
 				}
 				numLo++
 			}
-			for numHi < numContextRecords && numLo+numHi < numEqual && i != len(groups)-1 {
+			for numHi < maxContext && numLo+numHi < numEqual && i != len(groups)-1 {
 				if r := recs[numEqual-numHi-1].Value; r.NumIgnored > 0 && r.NumSame+r.NumDiff == 0 {
 					break
 				}
 				numHi++
 			}
-			if numEqual-(numLo+numHi) == 1 && ds.NumIgnored == 0 {
+			if numEqual-(numLo+numHi) == 1 && ds.NumIgnored == 0 && maxContext > 0 {
 				numHi++ // Avoid pointless coalescing of a single equal record
 			}
 
@@ -347,6 +394,17 @@ func (opts formatOptions) formatDiffList(recs []reportRecord, k reflect.Kind, pt
 					outx = opts2.WithDiffMode(diffRemoved).FormatDiff(r.Value, ptrs)
 					outy = opts2.WithDiffMode(diffInserted).FormatDiff(r.Value, ptrs)
 				}
+				lx, okx := outx.(textLine)
+				ly, oky := outy.(textLine)
+				if opts.SideBySide && okx && oky {
+					out := textLine(string(lx) + " → " + string(ly))
+					list = append(list, textRecord{Diff: diffModified, Key: formatKey(r.Key), Value: out})
+					if r.Value.Reason != "" {
+						list[len(list)-1].Comment = textLine(r.Value.Reason)
+					}
+					keys = append(keys, r.Key)
+					break
+				}
 				if outx != nil {
 					list = append(list, textRecord{Diff: diffRemoved, Key: formatKey(r.Key), Value: outx})
 					keys = append(keys, r.Key)
@@ -355,6 +413,9 @@ func (opts formatOptions) formatDiffList(recs []reportRecord, k reflect.Kind, pt
 					list = append(list, textRecord{Diff: diffInserted, Key: formatKey(r.Key), Value: outy})
 					keys = append(keys, r.Key)
 				}
+				if r.Value.Reason != "" && (outx != nil || outy != nil) {
+					list[len(list)-1].Comment = textLine(r.Value.Reason)
+				}
 			default:
 				out := opts.FormatDiff(r.Value, ptrs)
 				list = append(list, textRecord{Key: formatKey(r.Key), Value: out})
@@ -50,6 +50,9 @@ type formatValueOptions struct {
 // This may return s as-is depending on the current type and TypeMode mode.
 func (opts formatOptions) FormatType(t reflect.Type, s textNode) textNode {
 	// Check whether to emit the type or not.
+	if opts.ElideTypes {
+		return s
+	}
 	switch opts.TypeMode {
 	case autoType:
 		switch t.Kind() {
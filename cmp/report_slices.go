@@ -139,9 +139,7 @@ func (opts formatOptions) FormatDiffSlice(v *valueNode) textNode {
 		if isPureLinedText {
 			ssx = strings.Split(sx, "\n")
 			ssy = strings.Split(sy, "\n")
-			esLines := diff.Difference(len(ssx), len(ssy), func(ix, iy int) diff.Result {
-				return diff.BoolResult(ssx[ix] == ssy[iy])
-			})
+			esLines := opts.diffLines(ssx, ssy)
 			esBytes := diff.Difference(len(sx), len(sy), func(ix, iy int) diff.Result {
 				return diff.BoolResult(sx[ix] == sy[iy])
 			})
@@ -161,6 +159,11 @@ func (opts formatOptions) FormatDiffSlice(v *valueNode) textNode {
 	// If the text appears to be multi-lined text,
 	// then perform differencing across individual lines.
 	case isPureLinedText:
+		if opts.UnifiedDiff {
+			if list2, ok := opts.formatUnifiedDiff(ssx, ssy); ok {
+				return opts.formatTripleQuoted(t, list2)
+			}
+		}
 		list = opts.formatDiffSlice(
 			reflect.ValueOf(ssx), reflect.ValueOf(ssy), 1, "line",
 			func(v reflect.Value, d diffMode) textRecord {
@@ -232,19 +235,7 @@ func (opts formatOptions) FormatDiffSlice(v *valueNode) textNode {
 		}
 		list2 = append(list2, textRecord{Value: textLine(`"""`), ElideComma: true})
 		if isTripleQuoted {
-			var out textNode = &textWrap{Prefix: "(", Value: list2, Suffix: ")"}
-			switch t.Kind() {
-			case reflect.String:
-				if t != stringType {
-					out = opts.FormatType(t, out)
-				}
-			case reflect.Slice:
-				// Always emit type for slices since the triple-quote syntax
-				// looks like a string (not a slice).
-				opts = opts.WithTypeMode(emitType)
-				out = opts.FormatType(t, out)
-			}
-			return out
+			return opts.formatTripleQuoted(t, list2)
 		}
 
 	// If the text appears to be single-lined text,
@@ -354,6 +345,161 @@ func formatASCII(s string) string {
 	return string(b)
 }
 
+// formatTripleQuoted wraps a list of lines delimited by the custom
+// triple-quote (""") syntax within parenthesis, emitting the type if needed.
+func (opts formatOptions) formatTripleQuoted(t reflect.Type, list textList) textNode {
+	var out textNode = &textWrap{Prefix: "(", Value: list, Suffix: ")"}
+	switch t.Kind() {
+	case reflect.String:
+		if t != stringType {
+			out = opts.FormatType(t, out)
+		}
+	case reflect.Slice:
+		// Always emit type for slices since the triple-quote syntax
+		// looks like a string (not a slice).
+		opts = opts.WithTypeMode(emitType)
+		out = opts.FormatType(t, out)
+	}
+	return out
+}
+
+// formatUnifiedDiff formats the differences between the lines in ssx and ssy
+// as a unified diff delimited by the custom triple-quote (""") syntax.
+// Each hunk has up to NumContextRecords lines of context.
+// Removed and inserted lines are also marked as such in the report.
+// It reports false if any line contains non-printable characters.
+//
+// For example:
+//
+//		"""
+//		--- a
+//		+++ b
+//		@@ -2,3 +2,3 @@
+//		 foo
+//	-	-bar
+//	+	+BAR
+//		 baz
+//		"""
+func (opts formatOptions) formatUnifiedDiff(ssx, ssy []string) (textList, bool) {
+	isPrintable := func(r rune) bool {
+		return unicode.IsPrint(r) || r == '\t' // specially treat tab as printable
+	}
+	for _, s := range append(ssx[:len(ssx):len(ssx)], ssy...) {
+		if strings.TrimFunc(s, isPrintable) != "" {
+			return nil, false
+		}
+	}
+
+	// Avoid an empty context line for text that ends with a newline.
+	if n, m := len(ssx), len(ssy); ssx[n-1] == "" && ssy[m-1] == "" {
+		ssx, ssy = ssx[:n-1], ssy[:m-1]
+	}
+
+	// Compute the starting line offsets in x and y for each edit.
+	es := opts.diffLines(ssx, ssy)
+	ixs, iys := make([]int, len(es)+1), make([]int, len(es)+1)
+	for i, e := range es {
+		ixs[i+1], iys[i+1] = ixs[i], iys[i]
+		if e != diff.UniqueY {
+			ixs[i+1]++
+		}
+		if e != diff.UniqueX {
+			iys[i+1]++
+		}
+	}
+	hunkRange := func(start, count int) string {
+		switch count {
+		case 0:
+			return fmt.Sprintf("%d,0", start)
+		case 1:
+			return fmt.Sprintf("%d", start+1)
+		default:
+			return fmt.Sprintf("%d,%d", start+1, count)
+		}
+	}
+
+	numContext := opts.NumContextRecords
+	if numContext < 0 {
+		numContext = len(es)
+	}
+	var list textList
+	appendLine := func(d diffMode, s string) {
+		list = append(list, textRecord{Diff: d, Value: textLine(s), ElideComma: true})
+	}
+	appendLine(diffIdentical, `"""`)
+	appendLine(diffIdentical, "--- a")
+	appendLine(diffIdentical, "+++ b")
+	for i := 0; i < len(es); {
+		if es[i] == diff.Identity {
+			i++
+			continue
+		}
+
+		// Extend the hunk until the next edit is too far away to share
+		// context with the previous edit.
+		last := i
+		for j := i; j < len(es) && j-last <= 2*numContext+1; j++ {
+			if es[j] != diff.Identity {
+				last = j
+			}
+		}
+		start, end := max(i-numContext, 0), min(last+1+numContext, len(es))
+		appendLine(diffIdentical, fmt.Sprintf("@@ -%s +%s @@",
+			hunkRange(ixs[start], ixs[end]-ixs[start]),
+			hunkRange(iys[start], iys[end]-iys[start])))
+
+		// Group all removed lines before all inserted lines.
+		var removed, inserted []string
+		flush := func() {
+			for _, s := range removed {
+				appendLine(diffRemoved, "-"+s)
+			}
+			for _, s := range inserted {
+				appendLine(diffInserted, "+"+s)
+			}
+			removed, inserted = nil, nil
+		}
+		for k := start; k < end; k++ {
+			switch es[k] {
+			case diff.Identity:
+				flush()
+				appendLine(diffIdentical, " "+ssx[ixs[k]])
+			case diff.UniqueX:
+				removed = append(removed, ssx[ixs[k]])
+			case diff.UniqueY:
+				inserted = append(inserted, ssy[iys[k]])
+			case diff.Modified:
+				removed = append(removed, ssx[ixs[k]])
+				inserted = append(inserted, ssy[iys[k]])
+			}
+		}
+		flush()
+		i = end
+	}
+	appendLine(diffIdentical, `"""`)
+	return list, true
+}
+
+// diffLines computes an edit-script for the lines of text in ssx and ssy
+// using the configured string diffing algorithm.
+func (opts formatOptions) diffLines(ssx, ssy []string) diff.EditScript {
+	if opts.StringDiffAlgorithm == PatienceStringDiff {
+		return diff.PatienceDiff(ssx, ssy)
+	}
+	return diff.Difference(len(ssx), len(ssy), func(ix, iy int) diff.Result {
+		return diff.BoolResult(ssx[ix] == ssy[iy])
+	})
+}
+
+// stringsOf returns the elements of v, which must be a slice of a string kind.
+func stringsOf(v reflect.Value) []string {
+	ss := make([]string, v.Len())
+	for i := range ss {
+		ss[i] = v.Index(i).String()
+	}
+	return ss
+}
+
 func (opts formatOptions) formatDiffSlice(
 	vx, vy reflect.Value, chunkSize int, name string,
 	makeRec func(reflect.Value, diffMode) textRecord,
@@ -361,9 +507,14 @@ func (opts formatOptions) formatDiffSlice(
 	eq := func(ix, iy int) bool {
 		return vx.Index(ix).Interface() == vy.Index(iy).Interface()
 	}
-	es := diff.Difference(vx.Len(), vy.Len(), func(ix, iy int) diff.Result {
-		return diff.BoolResult(eq(ix, iy))
-	})
+	var es diff.EditScript
+	if vx.Kind() == reflect.Slice && vx.Type().Elem().Kind() == reflect.String {
+		es = opts.diffLines(stringsOf(vx), stringsOf(vy))
+	} else {
+		es = diff.Difference(vx.Len(), vy.Len(), func(ix, iy int) diff.Result {
+			return diff.BoolResult(eq(ix, iy))
+		})
+	}
 
 	appendChunks := func(v reflect.Value, d diffMode) int {
 		n0 := v.Len()
@@ -400,13 +551,17 @@ func (opts formatOptions) formatDiffSlice(
 			// Compute the number of leading and trailing equal bytes to print.
 			var numLo, numHi int
 			numEqual := ds.NumIgnored + ds.NumIdentical
-			for numLo < chunkSize*numContextRecords && numLo+numHi < numEqual && i != 0 {
+			maxContext := chunkSize * opts.NumContextRecords
+			if opts.NumContextRecords < 0 {
+				maxContext = numEqual
+			}
+			for numLo < maxContext && numLo+numHi < numEqual && i != 0 {
 				numLo++
 			}
-			for numHi < chunkSize*numContextRecords && numLo+numHi < numEqual && i != len(groups)-1 {
+			for numHi < maxContext && numLo+numHi < numEqual && i != len(groups)-1 {
 				numHi++
 			}
-			if numEqual-(numLo+numHi) <= chunkSize && ds.NumIgnored == 0 {
+			if numEqual-(numLo+numHi) <= chunkSize && ds.NumIgnored == 0 && maxContext > 0 {
 				numHi = numEqual - numLo // Avoid pointless coalescing of single equal row
 			}
 
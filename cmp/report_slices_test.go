@@ -0,0 +1,29 @@
+// Copyright 2026, The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmp
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Test that slices of a named string type are differenced with the same
+// line diffing algorithm as []string.
+func TestFormatDiffSliceNamedStrings(t *testing.T) {
+	type Lines []string
+	x := []string{"func A() {", "}", "func B() {", "}", "func C() {", "}", "func D() {", "}"}
+	y := []string{"func A() {", "}", "func D() {", "}", "func B() {", "}", "func C() {", "}"}
+	makeRec := func(v reflect.Value, d diffMode) textRecord {
+		return textRecord{Diff: d, Value: textLine(v.Index(0).String())}
+	}
+	for _, alg := range []StringDiffAlgorithm{DefaultStringDiff, PatienceStringDiff} {
+		opts := formatOptions{StringDiffAlgorithm: alg, NumContextRecords: -1}
+		want := opts.formatDiffSlice(reflect.ValueOf(x), reflect.ValueOf(y), 1, "line", makeRec)
+		got := opts.formatDiffSlice(reflect.ValueOf(Lines(x)), reflect.ValueOf(Lines(y)), 1, "line", makeRec)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("formatDiffSlice(%v):\ngot  %v\nwant %v", alg, got, want)
+		}
+	}
+}
@@ -36,6 +36,8 @@ func (n indentMode) appendIndent(b []byte, d diffMode) []byte {
 			b = append(b, "- "...)
 		case diffInserted:
 			b = append(b, "+ "...)
+		case diffModified:
+			b = append(b, "~ "...)
 		}
 	} else {
 		// Use non-breaking spaces (U+00a0).
@@ -46,6 +48,8 @@ func (n indentMode) appendIndent(b []byte, d diffMode) []byte {
 			b = append(b, "- "...)
 		case diffInserted:
 			b = append(b, "+ "...)
+		case diffModified:
+			b = append(b, "~ "...)
 		}
 	}
 	return repeatCount(n).appendChar(b, '\t')
@@ -206,7 +210,7 @@ func (s textList) formatCompactTo(b []byte, d diffMode) ([]byte, textNode) {
 	n0 := len(b) // Original buffer length
 	var multiLine bool
 	for i, r := range s {
-		if r.Diff == diffInserted || r.Diff == diffRemoved {
+		if r.Diff == diffInserted || r.Diff == diffRemoved || r.Diff == diffModified {
 			multiLine = true
 		}
 		b = append(b, r.Key...)
@@ -44,6 +44,10 @@ type valueNode struct {
 
 	// TransformerName is the name of the transformer.
 	TransformerName string // If non-empty, implies Value is populated
+
+	// Reason is the reason provided by a comparer for why a leaf node
+	// is not equal.
+	Reason string
 }
 type reportRecord struct {
 	Key   reflect.Value // Invalid for slice element
@@ -99,6 +103,7 @@ func (r *valueNode) Report(rs Result) {
 	}
 	if rs.ByFunc() {
 		r.NumCompared++
+		r.Reason = rs.Reason()
 	}
 	assert(r.NumCompared <= 1)
 }